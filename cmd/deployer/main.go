@@ -30,6 +30,7 @@ import (
 
 	"github.com/k8stopologyawareschedwg/deployer/pkg/commands"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 	deployerversion "github.com/k8stopologyawareschedwg/deployer/pkg/version"
 )
@@ -69,6 +70,8 @@ func main() {
 		Log: stdr.New(log.New(os.Stderr, "", log.LstdFlags)),
 	}
 
+	manifests.DeployerVersion = deployerversion.GitVersion
+
 	root := commands.NewRootCommand(&env, NewVersionCommand)
 	if err := root.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
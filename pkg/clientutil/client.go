@@ -29,11 +29,13 @@ import (
 
 	configv1 "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
 
+	nrtv1alpha2 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha2"
 	topologyclientset "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned"
 )
 
 func init() {
 	apiextensionsv1.AddToScheme(scheme.Scheme)
+	nrtv1alpha2.AddToScheme(scheme.Scheme)
 }
 
 // New returns a controller-runtime client.
@@ -0,0 +1,211 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/pflag"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/config"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+)
+
+type flagValue struct {
+	name  string
+	value string
+}
+
+// ApplyConfigFile loads the configuration file, if given, and uses its values
+// for all the flags not explicitly set on the command line. Hence the precedence
+// is: command line flags, then configuration file, then flag defaults.
+// The configuration values of flags not supported by the current command are ignored.
+func ApplyConfigFile(env *deployer.Environment, flags *pflag.FlagSet, commonOpts *options.Options, internalOpts *internalOptions) error {
+	if internalOpts.configFile == "" {
+		return nil
+	}
+	cfg, err := config.Load(internalOpts.configFile)
+	if err != nil {
+		return err
+	}
+
+	for _, fv := range flagValuesFromConfig(cfg) {
+		flag := flags.Lookup(fv.name)
+		if flag == nil || flag.Changed {
+			continue
+		}
+		if err := flags.Set(fv.name, fv.value); err != nil {
+			return fmt.Errorf("%s: invalid value %q for %q: %w", internalOpts.configFile, fv.value, fv.name, err)
+		}
+	}
+
+	// the side files, if given, are read later and take precedence
+	if cfg.Updater != nil {
+		commonOpts.RTEConfigData, err = cfg.Updater.Config.ToYAML()
+		if err != nil {
+			return err
+		}
+		if cfg.Updater.Pod != nil {
+			commonOpts.UpdaterPodSettings = podSettingsFromConfig(*cfg.Updater.Pod)
+		}
+	}
+	if cfg.Scheduler != nil {
+		commonOpts.SchedScoringStratConfigData, err = cfg.Scheduler.ScoringStrategy.ToYAML()
+		if err != nil {
+			return err
+		}
+		commonOpts.SchedCacheParamsConfigData, err = cfg.Scheduler.CacheParams.ToYAML()
+		if err != nil {
+			return err
+		}
+		commonOpts.SchedProfiles, err = schedProfilesFromConfig(cfg.Scheduler.Profiles)
+		if err != nil {
+			return err
+		}
+		commonOpts.SchedPlugins, err = schedPluginsFromConfig(cfg.Scheduler.Plugins)
+		if err != nil {
+			return err
+		}
+		if cfg.Scheduler.Pod != nil {
+			commonOpts.SchedPodSettings = podSettingsFromConfig(*cfg.Scheduler.Pod)
+		}
+		if cfg.Scheduler.ControllerPod != nil {
+			commonOpts.SchedCtrlPodSettings = podSettingsFromConfig(*cfg.Scheduler.ControllerPod)
+		}
+	}
+	env.Log.V(3).Info("configuration file loaded", "path", internalOpts.configFile)
+	return nil
+}
+
+func schedProfilesFromConfig(profCfgs []config.SchedulerProfileConfig) ([]options.SchedulerProfile, error) {
+	var profs []options.SchedulerProfile
+	for _, profCfg := range profCfgs {
+		scoringStratConfigData, err := profCfg.ScoringStrategy.ToYAML()
+		if err != nil {
+			return nil, err
+		}
+		cacheParamsConfigData, err := profCfg.CacheParams.ToYAML()
+		if err != nil {
+			return nil, err
+		}
+		plugins, err := schedPluginsFromConfig(profCfg.Plugins)
+		if err != nil {
+			return nil, err
+		}
+		profs = append(profs, options.SchedulerProfile{
+			Name:                   profCfg.Name,
+			ScoringStratConfigData: scoringStratConfigData,
+			CacheParamsConfigData:  cacheParamsConfigData,
+			PreemptionMode:         profCfg.PreemptionMode,
+			Plugins:                plugins,
+		})
+	}
+	return profs, nil
+}
+
+func schedPluginsFromConfig(pluginCfgs []config.SchedulerPluginConfig) ([]options.SchedulerPlugin, error) {
+	var plugins []options.SchedulerPlugin
+	for _, pluginCfg := range pluginCfgs {
+		argsData, err := pluginCfg.Args.ToYAML()
+		if err != nil {
+			return nil, err
+		}
+		plugins = append(plugins, options.SchedulerPlugin{
+			Name:       pluginCfg.Name,
+			EnabledAt:  pluginCfg.EnabledAt,
+			DisabledAt: pluginCfg.DisabledAt,
+			Weight:     pluginCfg.Weight,
+			ArgsData:   argsData,
+		})
+	}
+	return plugins, nil
+}
+
+func podSettingsFromConfig(podCfg config.PodConfig) options.PodSettings {
+	return options.PodSettings{
+		Resources:                 podCfg.Resources,
+		PriorityClassName:         podCfg.PriorityClassName,
+		Tolerations:               podCfg.Tolerations,
+		NodeAffinity:              podCfg.NodeAffinity,
+		TopologySpreadConstraints: podCfg.TopologySpreadConstraints,
+		PodAntiAffinity:           podCfg.PodAntiAffinity,
+	}
+}
+
+func flagValuesFromConfig(cfg config.Config) []flagValue {
+	var fvs []flagValue
+	addInt := func(name string, val *int) {
+		if val != nil {
+			fvs = append(fvs, flagValue{name: name, value: strconv.Itoa(*val)})
+		}
+	}
+	addBool := func(name string, val *bool) {
+		if val != nil {
+			fvs = append(fvs, flagValue{name: name, value: strconv.FormatBool(*val)})
+		}
+	}
+	addString := func(name string, val string) {
+		if val != "" {
+			fvs = append(fvs, flagValue{name: name, value: val})
+		}
+	}
+	addDuration := func(name string, val *metav1.Duration) {
+		if val != nil {
+			fvs = append(fvs, flagValue{name: name, value: val.Duration.String()})
+		}
+	}
+
+	addInt("verbose", cfg.Verbose)
+	addString("platform", cfg.Platform)
+	addInt("replicas", cfg.Replicas)
+	addBool("pull-if-not-present", cfg.PullIfNotPresent)
+	addBool("apply", cfg.Apply)
+	addBool("transactional", cfg.Transactional)
+	addInt("parallelism", cfg.Parallelism)
+	addString("install-id", cfg.InstallID)
+	addString("inventory-namespace", cfg.InventoryNamespace)
+	if cfg.Wait != nil {
+		addBool("wait", cfg.Wait.Completion)
+		addDuration("wait-interval", cfg.Wait.Interval)
+		addDuration("wait-timeout", cfg.Wait.Timeout)
+	}
+	if upd := cfg.Updater; upd != nil {
+		addString("updater-type", upd.Type)
+		addBool("updater-pfp-enable", upd.PFPEnable)
+		addBool("updater-notif-enable", upd.NotifEnable)
+		addBool("updater-cri-hooks-enable", upd.CRIHooksEnable)
+		addBool("updater-custom-selinux-policy", upd.CustomSELinuxPolicy)
+		addString("updater-scc", upd.SCCVersion)
+		addDuration("updater-sync-period", upd.SyncPeriod)
+		addInt("updater-verbose", upd.Verbose)
+	}
+	if sch := cfg.Scheduler; sch != nil {
+		addString("sched-profile-name", sch.ProfileName)
+		addDuration("sched-resync-period", sch.ResyncPeriod)
+		addInt("sched-verbose", sch.Verbose)
+		addBool("sched-ctrlplane-affinity", sch.CtrlPlaneAffinity)
+		addString("sched-leader-elect-resource", sch.LeaderElectResource)
+		addString("sched-preemption-mode", sch.PreemptionMode)
+		addString("sched-informer-mode", sch.InformerMode)
+		addString("sched-config-api-version", sch.ConfigAPIVersion)
+	}
+	return fvs
+}
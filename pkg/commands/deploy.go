@@ -18,6 +18,7 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform/detect"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/sched"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/updaters"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 )
 
@@ -41,6 +43,10 @@ func NewDeployCommand(env *deployer.Environment, commonOpts *options.Options) *c
 		Args: cobra.NoArgs,
 	}
 	deploy.PersistentFlags().BoolVarP(&commonOpts.WaitCompletion, "wait", "W", false, "wait for deployment to be all completed.")
+	deploy.PersistentFlags().BoolVar(&commonOpts.Apply, "apply", false, "create missing objects and reconcile existing ones using server-side apply.")
+	deploy.PersistentFlags().StringVar(&commonOpts.DryRun, "dry-run", options.DryRunNone, "if \"server\", send all the requests as server-side dry-run: validate them without persisting anything.")
+	deploy.PersistentFlags().BoolVar(&commonOpts.Transactional, "transactional", false, "on failure, delete the objects created and restore the objects changed by this invocation.")
+	deploy.Flags().IntVar(&commonOpts.Parallelism, "parallelism", 1, "create and wait for up to this many independent objects concurrently. Use 1 to deploy sequentially.")
 	deploy.AddCommand(NewDeployAPICommand(env, commonOpts))
 	deploy.AddCommand(NewDeploySchedulerPluginCommand(env, commonOpts))
 	deploy.AddCommand(NewDeployTopologyUpdaterCommand(env, commonOpts))
@@ -68,10 +74,17 @@ func NewDeployAPICommand(env *deployer.Environment, commonOpts *options.Options)
 			}
 
 			env.Log.V(3).Info("detection", "platform", commonOpts.ClusterPlatform, "reason", reason, "version", commonOpts.ClusterVersion, "source", source)
-			if err := api.Deploy(env, options.API{Platform: commonOpts.ClusterPlatform}); err != nil {
-				return err
-			}
-			return nil
+			return deploy.Transactionally(env, commonOpts.Transactional, func(env *deployer.Environment) error {
+				err := api.Deploy(env, options.API{
+					Platform:  commonOpts.ClusterPlatform,
+					Apply:     commonOpts.Apply,
+					InstallID: commonOpts.InstallID,
+				})
+				if err != nil {
+					return err
+				}
+				return deploy.RecordInventory(env, commonOpts, manifests.ComponentAPI)
+			})
 		},
 		Args: cobra.NoArgs,
 	}
@@ -101,19 +114,12 @@ func NewDeploySchedulerPluginCommand(env *deployer.Environment, commonOpts *opti
 			}
 
 			env.Log.V(3).Info("detection", "platform", commonOpts.ClusterPlatform, "reason", reason, "version", commonOpts.ClusterVersion, "source", source)
-			return sched.Deploy(env, options.Scheduler{
-				Platform:               commonOpts.ClusterPlatform,
-				WaitCompletion:         commonOpts.WaitCompletion,
-				Replicas:               int32(commonOpts.Replicas),
-				PullIfNotPresent:       commonOpts.PullIfNotPresent,
-				ProfileName:            commonOpts.SchedProfileName,
-				CacheResyncPeriod:      commonOpts.SchedResyncPeriod,
-				CtrlPlaneAffinity:      commonOpts.SchedCtrlPlaneAffinity,
-				Verbose:                commonOpts.SchedVerbose,
-				ScoringStratConfigData: commonOpts.SchedScoringStratConfigData,
-				CacheParamsConfigData:  commonOpts.SchedCacheParamsConfigData,
-				LeaderElection:         commonOpts.Replicas > 1,
-				LeaderElectionResource: commonOpts.SchedLeaderElectResource,
+			return deploy.Transactionally(env, commonOpts.Transactional, func(env *deployer.Environment) error {
+				err := sched.Deploy(env, deploy.SchedulerOptionsFrom(commonOpts))
+				if err != nil {
+					return err
+				}
+				return deploy.RecordInventory(env, commonOpts, manifests.ComponentSchedulerPlugin)
 			})
 		},
 		Args: cobra.NoArgs,
@@ -144,14 +150,22 @@ func NewDeployTopologyUpdaterCommand(env *deployer.Environment, commonOpts *opti
 			}
 
 			env.Log.V(3).Info("detection", "platform", commonOpts.ClusterPlatform, "reason", reason, "version", commonOpts.ClusterVersion, "source", source)
-			return updaters.Deploy(env, commonOpts.UpdaterType, options.Updater{
-				Platform:            commonOpts.ClusterPlatform,
-				PlatformVersion:     commonOpts.ClusterVersion,
-				WaitCompletion:      commonOpts.WaitCompletion,
-				RTEConfigData:       commonOpts.RTEConfigData,
-				DaemonSet:           options.ForDaemonSet(commonOpts),
-				EnableCRIHooks:      commonOpts.UpdaterCRIHooksEnable,
-				CustomSELinuxPolicy: commonOpts.UpdaterCustomSELinuxPolicy,
+			return deploy.Transactionally(env, commonOpts.Transactional, func(env *deployer.Environment) error {
+				err := updaters.Deploy(env, commonOpts.UpdaterType, options.Updater{
+					Platform:            commonOpts.ClusterPlatform,
+					PlatformVersion:     commonOpts.ClusterVersion,
+					WaitCompletion:      commonOpts.WaitCompletion,
+					Apply:               commonOpts.Apply,
+					RTEConfigData:       commonOpts.RTEConfigData,
+					DaemonSet:           options.ForDaemonSet(commonOpts),
+					EnableCRIHooks:      commonOpts.UpdaterCRIHooksEnable,
+					CustomSELinuxPolicy: commonOpts.UpdaterCustomSELinuxPolicy,
+					InstallID:           commonOpts.InstallID,
+				})
+				if err != nil {
+					return err
+				}
+				return deploy.RecordInventory(env, commonOpts, strings.ToLower(commonOpts.UpdaterType))
 			})
 		},
 		Args: cobra.NoArgs,
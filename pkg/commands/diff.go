@@ -0,0 +1,67 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform/detect"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/diff"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+)
+
+func NewDiffCommand(env *deployer.Environment, commonOpts *options.Options) *cobra.Command {
+	diffCmd := &cobra.Command{
+		Use:   "diff",
+		Short: "show the differences between the rendered manifests and the live cluster objects",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := env.EnsureClient(); err != nil {
+				return err
+			}
+
+			if commonOpts.UserPlatform == platform.Unknown {
+				platDetect, reason, _ := detect.FindPlatform(env.Ctx, commonOpts.UserPlatform)
+				if platDetect.Discovered == platform.Unknown {
+					return fmt.Errorf("cannot autodetect the platform, and no platform given")
+				}
+				versionDetect, source, _ := detect.FindVersion(env.Ctx, platDetect.Discovered, commonOpts.UserPlatformVersion)
+				env.Log.V(3).Info("detection", "platform", platDetect.Discovered, "reason", reason, "version", versionDetect.Discovered, "source", source)
+				// render what we would render if the user passed the detected platform
+				commonOpts.UserPlatform = platDetect.Discovered
+				commonOpts.UserPlatformVersion = versionDetect.Discovered
+			}
+
+			objs, err := makeManifestObjects(env, commonOpts)
+			if err != nil {
+				return err
+			}
+			res, err := diff.Objects(env.Ctx, env.Cli, objs)
+			if err != nil {
+				return err
+			}
+			res.Write(os.Stdout)
+			return nil
+		},
+		Args: cobra.NoArgs,
+	}
+	return diffCmd
+}
@@ -17,20 +17,30 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deploy"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/api"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform/detect"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/sched"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/updaters"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 )
 
+type removeOptions struct {
+	discover     bool
+	pruneOrphans bool
+}
+
 func NewRemoveCommand(env *deployer.Environment, commonOpts *options.Options) *cobra.Command {
+	opts := &removeOptions{}
 	remove := &cobra.Command{
 		Use:   "remove",
 		Short: "remove the components and configurations needed for topology-aware-scheduling",
@@ -53,23 +63,16 @@ func NewRemoveCommand(env *deployer.Environment, commonOpts *options.Options) *c
 			}
 			env.Log.V(3).Info("detection", "platform", commonOpts.ClusterPlatform, "reason", reason, "version", commonOpts.ClusterVersion, "source", source)
 
-			err = sched.Remove(env, options.Scheduler{
-				Platform:               commonOpts.ClusterPlatform,
-				WaitCompletion:         commonOpts.WaitCompletion,
-				Replicas:               int32(commonOpts.Replicas),
-				PullIfNotPresent:       commonOpts.PullIfNotPresent,
-				ProfileName:            commonOpts.SchedProfileName,
-				CacheResyncPeriod:      commonOpts.SchedResyncPeriod,
-				CtrlPlaneAffinity:      commonOpts.SchedCtrlPlaneAffinity,
-				Verbose:                commonOpts.SchedVerbose,
-				ScoringStratConfigData: commonOpts.SchedScoringStratConfigData,
-				CacheParamsConfigData:  commonOpts.SchedCacheParamsConfigData,
-				LeaderElection:         commonOpts.Replicas > 1,
-				LeaderElectionResource: commonOpts.SchedLeaderElectResource,
-			})
+			if opts.discover || opts.pruneOrphans {
+				return removeDiscovered(env, commonOpts, opts)
+			}
+
+			var errs []error
+			err = sched.Remove(env, deploy.SchedulerOptionsFrom(commonOpts))
 			if err != nil {
 				// intentionally keep going to remove as much as possible
 				env.Log.Info("while removing", "error", err)
+				errs = append(errs, err)
 			}
 			err = updaters.Remove(env, commonOpts.UpdaterType, options.Updater{
 				Platform:        commonOpts.ClusterPlatform,
@@ -82,6 +85,7 @@ func NewRemoveCommand(env *deployer.Environment, commonOpts *options.Options) *c
 			if err != nil {
 				// intentionally keep going to remove as much as possible
 				env.Log.Info("while removing", "error", err)
+				errs = append(errs, err)
 			}
 			err = api.Remove(env, options.API{
 				Platform: commonOpts.ClusterPlatform,
@@ -89,18 +93,62 @@ func NewRemoveCommand(env *deployer.Environment, commonOpts *options.Options) *c
 			if err != nil {
 				// intentionally keep going to remove as much as possible
 				env.Log.Info("while removing", "error", err)
+				errs = append(errs, err)
+			}
+			if env.DryRun {
+				// report the objects the apiserver would reject
+				return errors.Join(errs...)
 			}
-			return nil
+			if len(errs) > 0 {
+				// keep the inventory to know what is left
+				return nil
+			}
+			return deploy.ForgetInventory(env, commonOpts, manifests.ComponentAPI, strings.ToLower(commonOpts.UpdaterType), manifests.ComponentSchedulerPlugin)
 		},
 		Args: cobra.NoArgs,
 	}
 	remove.PersistentFlags().BoolVarP(&commonOpts.WaitCompletion, "wait", "W", false, "wait for removal to be all completed.")
+	remove.PersistentFlags().StringVar(&commonOpts.DryRun, "dry-run", options.DryRunNone, "if \"server\", send all the requests as server-side dry-run: validate them without persisting anything.")
+	remove.Flags().BoolVar(&opts.discover, "discover", false, "remove the objects found installed, by inventory and by labels, regardless of the current options.")
+	remove.Flags().BoolVar(&opts.pruneOrphans, "prune-orphans", false, "report and remove the objects found installed which the current options would not create.")
+	remove.MarkFlagsMutuallyExclusive("discover", "prune-orphans")
 	remove.AddCommand(NewRemoveAPICommand(env, commonOpts))
 	remove.AddCommand(NewRemoveSchedulerPluginCommand(env, commonOpts))
 	remove.AddCommand(NewRemoveTopologyUpdaterCommand(env, commonOpts))
 	return remove
 }
 
+func removeDiscovered(env *deployer.Environment, commonOpts *options.Options, opts *removeOptions) error {
+	objs, err := deploy.Discover(env, commonOpts)
+	if err != nil {
+		return err
+	}
+	if opts.pruneOrphans {
+		objs, err = deploy.Orphans(env, commonOpts, objs)
+		if err != nil {
+			return err
+		}
+		// the orphans are the report users asked for: print them on stdout, one per line
+		for _, obj := range objs {
+			fmt.Printf("orphan %s\n", obj.String())
+		}
+	}
+	if len(objs) == 0 {
+		env.Log.Info("nothing to remove", "installID", commonOpts.InstallID)
+		return nil
+	}
+
+	env.Log.Info("removing discovered objects", "installID", commonOpts.InstallID, "count", len(objs))
+	if err := deploy.RemoveObjects(env, commonOpts, objs); err != nil {
+		// keep the inventory to know what is left
+		return err
+	}
+	if env.DryRun {
+		return nil
+	}
+	return deploy.ForgetObjects(env, commonOpts, objs)
+}
+
 func NewRemoveAPICommand(env *deployer.Environment, commonOpts *options.Options) *cobra.Command {
 	remove := &cobra.Command{
 		Use:   "api",
@@ -127,7 +175,7 @@ func NewRemoveAPICommand(env *deployer.Environment, commonOpts *options.Options)
 			if err := api.Remove(env, options.API{Platform: commonOpts.ClusterPlatform}); err != nil {
 				return err
 			}
-			return nil
+			return deploy.ForgetInventory(env, commonOpts, manifests.ComponentAPI)
 		},
 		Args: cobra.NoArgs,
 	}
@@ -157,20 +205,11 @@ func NewRemoveSchedulerPluginCommand(env *deployer.Environment, commonOpts *opti
 			}
 
 			env.Log.V(3).Info("detection", "platform", commonOpts.ClusterPlatform, "reason", reason, "version", commonOpts.ClusterVersion, "source", source)
-			return sched.Remove(env, options.Scheduler{
-				Platform:               commonOpts.ClusterPlatform,
-				WaitCompletion:         commonOpts.WaitCompletion,
-				Replicas:               int32(commonOpts.Replicas),
-				PullIfNotPresent:       commonOpts.PullIfNotPresent,
-				ProfileName:            commonOpts.SchedProfileName,
-				CacheResyncPeriod:      commonOpts.SchedResyncPeriod,
-				CtrlPlaneAffinity:      commonOpts.SchedCtrlPlaneAffinity,
-				Verbose:                commonOpts.SchedVerbose,
-				ScoringStratConfigData: commonOpts.SchedScoringStratConfigData,
-				CacheParamsConfigData:  commonOpts.SchedCacheParamsConfigData,
-				LeaderElection:         commonOpts.Replicas > 1,
-				LeaderElectionResource: commonOpts.SchedLeaderElectResource,
-			})
+			err = sched.Remove(env, deploy.SchedulerOptionsFrom(commonOpts))
+			if err != nil {
+				return err
+			}
+			return deploy.ForgetInventory(env, commonOpts, manifests.ComponentSchedulerPlugin)
 		},
 		Args: cobra.NoArgs,
 	}
@@ -200,7 +239,7 @@ func NewRemoveTopologyUpdaterCommand(env *deployer.Environment, commonOpts *opti
 			}
 
 			env.Log.V(3).Info("detection", "platform", commonOpts.ClusterPlatform, "reason", reason, "version", commonOpts.ClusterVersion, "source", source)
-			return updaters.Remove(env, commonOpts.UpdaterType, options.Updater{
+			err = updaters.Remove(env, commonOpts.UpdaterType, options.Updater{
 				Platform:            commonOpts.ClusterPlatform,
 				PlatformVersion:     commonOpts.ClusterVersion,
 				WaitCompletion:      commonOpts.WaitCompletion,
@@ -209,6 +248,10 @@ func NewRemoveTopologyUpdaterCommand(env *deployer.Environment, commonOpts *opti
 				EnableCRIHooks:      commonOpts.UpdaterCRIHooksEnable,
 				CustomSELinuxPolicy: commonOpts.UpdaterCustomSELinuxPolicy,
 			})
+			if err != nil {
+				return err
+			}
+			return deploy.ForgetInventory(env, commonOpts, strings.ToLower(commonOpts.UpdaterType))
 		},
 		Args: cobra.NoArgs,
 	}
@@ -19,22 +19,44 @@ package commands
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
+
+	k8sversion "k8s.io/apimachinery/pkg/util/version"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	selinuxassets "github.com/k8stopologyawareschedwg/deployer/pkg/assets/selinux"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deploy"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/updaters"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/helm"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/kustomize"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
 	apimanifests "github.com/k8stopologyawareschedwg/deployer/pkg/manifests/api"
 	schedmanifests "github.com/k8stopologyawareschedwg/deployer/pkg/manifests/sched"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/objectwait"
+	apiwait "github.com/k8stopologyawareschedwg/deployer/pkg/objectwait/api"
+	schedwait "github.com/k8stopologyawareschedwg/deployer/pkg/objectwait/sched"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 )
 
+const (
+	OutputFormatYAML      = "yaml"
+	OutputFormatKustomize = "kustomize"
+)
+
+type renderOptions struct {
+	outputFormat string
+	output       string
+	outputDir    string
+	overlays     []string
+}
+
 func NewRenderCommand(env *deployer.Environment, commonOpts *options.Options) *cobra.Command {
 	opts := &options.Scheduler{}
+	renderOpts := &renderOptions{}
 	render := &cobra.Command{
 		Use:   "render",
 		Short: "render all the manifests",
@@ -42,14 +64,41 @@ func NewRenderCommand(env *deployer.Environment, commonOpts *options.Options) *c
 			if commonOpts.UserPlatform == platform.Unknown {
 				return fmt.Errorf("must explicitly select a cluster platform")
 			}
-			return RenderManifests(env, commonOpts)
+			switch renderOpts.outputFormat {
+			case OutputFormatYAML:
+				if len(renderOpts.overlays) > 0 {
+					return fmt.Errorf("--overlay requires --output-format=%s", OutputFormatKustomize)
+				}
+				if renderOpts.outputDir != "" {
+					if err := requireYAMLOutput(cmd, "--output-dir"); err != nil {
+						return err
+					}
+					return RenderManifestsToDir(env, commonOpts, renderOpts.outputDir)
+				}
+				return RenderManifests(env, commonOpts, renderOpts.output)
+			case OutputFormatKustomize:
+				if err := requireYAMLOutput(cmd, "--output-format="+OutputFormatKustomize); err != nil {
+					return err
+				}
+				if renderOpts.outputDir == "" {
+					return fmt.Errorf("--output-format=%s requires --output-dir", OutputFormatKustomize)
+				}
+				return RenderKustomize(env, commonOpts, renderOpts.outputDir, renderOpts.overlays)
+			default:
+				return fmt.Errorf("unsupported output format: %q", renderOpts.outputFormat)
+			}
 		},
 		Args: cobra.NoArgs,
 	}
+	render.Flags().StringVar(&renderOpts.outputFormat, "output-format", OutputFormatYAML, "output format: \"yaml\" renders a multi-document stream, \"kustomize\" writes a kustomize tree in --output-dir.")
+	render.PersistentFlags().StringVar(&renderOpts.output, "output", manifests.OutputYAML, "serialization of the objects rendered on stdout: \"yaml\" (multi-document stream), \"json\" (a v1.List) or \"jsonl\" (one object per line).")
+	render.PersistentFlags().StringVar(&renderOpts.outputDir, "output-dir", "", "write the objects in this directory, one file per object named after the apply order, plus an index, instead of stdout.")
+	render.Flags().StringArrayVar(&renderOpts.overlays, "overlay", nil, "platform kind:version to render an overlay for, besides the one selected with --platform. Can be repeated.")
 	render.AddCommand(NewRenderAPICommand(env, commonOpts, opts))
 	render.AddCommand(NewRenderSchedulerPluginCommand(env, commonOpts, opts))
 	render.AddCommand(NewRenderTopologyUpdaterCommand(env, commonOpts, opts))
 	render.AddCommand(NewRenderPolicyCommand(env, commonOpts, opts))
+	render.AddCommand(NewRenderHelmChartCommand(env, commonOpts))
 	return render
 }
 
@@ -71,7 +120,15 @@ func NewRenderAPICommand(env *deployer.Environment, commonOpts *options.Options,
 			if err != nil {
 				return err
 			}
-			return manifests.RenderObjects(apiObjs.ToObjects(), os.Stdout)
+			if outputDir := outputDirFrom(cmd); outputDir != "" {
+				if err := requireYAMLOutput(cmd, "--output-dir"); err != nil {
+					return err
+				}
+				objs := waitableObjects(apiwait.Creatable(apiObjs, env.Cli, env.Log))
+				return renderObjectsToDir(env, manifests.StampObjects(objs, manifests.ComponentAPI, commonOpts.InstallID), outputDir)
+			}
+			objs := manifests.StampObjects(apiObjs.ToObjects(), manifests.ComponentAPI, commonOpts.InstallID)
+			return manifests.RenderObjectsAs(objs, outputFrom(cmd), os.Stdout)
 		},
 		Args: cobra.NoArgs,
 	}
@@ -100,20 +157,20 @@ func NewRenderSchedulerPluginCommand(env *deployer.Environment, commonOpts *opti
 				return err
 			}
 
-			renderOpts := options.Scheduler{
-				Replicas:               int32(commonOpts.Replicas),
-				PullIfNotPresent:       commonOpts.PullIfNotPresent,
-				CacheResyncPeriod:      commonOpts.SchedResyncPeriod,
-				ScoringStratConfigData: commonOpts.SchedScoringStratConfigData,
-				CacheParamsConfigData:  commonOpts.SchedCacheParamsConfigData,
-				LeaderElection:         commonOpts.Replicas > 1,
-				LeaderElectionResource: commonOpts.SchedLeaderElectResource,
-			}
+			renderOpts := renderSchedulerOptionsFrom(commonOpts)
 			schedObjs, err := schedManifests.Render(env.Log, renderOpts)
 			if err != nil {
 				return err
 			}
-			return manifests.RenderObjects(schedObjs.ToObjects(), os.Stdout)
+			if outputDir := outputDirFrom(cmd); outputDir != "" {
+				if err := requireYAMLOutput(cmd, "--output-dir"); err != nil {
+					return err
+				}
+				objs := waitableObjects(schedwait.Creatable(schedObjs, env.Cli, env.Log))
+				return renderObjectsToDir(env, manifests.StampObjects(objs, manifests.ComponentSchedulerPlugin, commonOpts.InstallID), outputDir)
+			}
+			objs := manifests.StampObjects(schedObjs.ToObjects(), manifests.ComponentSchedulerPlugin, commonOpts.InstallID)
+			return manifests.RenderObjectsAs(objs, outputFrom(cmd), os.Stdout)
 		},
 		Args: cobra.NoArgs,
 	}
@@ -128,18 +185,29 @@ func NewRenderTopologyUpdaterCommand(env *deployer.Environment, commonOpts *opti
 			if commonOpts.UserPlatform == platform.Unknown {
 				return fmt.Errorf("must explicitly select a cluster platform")
 			}
-			objs, _, err := makeUpdaterObjects(commonOpts)
+			if outputDir := outputDirFrom(cmd); outputDir != "" {
+				if err := requireYAMLOutput(cmd, "--output-dir"); err != nil {
+					return err
+				}
+				objs, _, err := makeUpdaterObjects(commonOpts, updaters.GetCreatableObjects)
+				if err != nil {
+					return err
+				}
+				return renderObjectsToDir(env, objs, outputDir)
+			}
+			objs, _, err := makeUpdaterObjects(commonOpts, updaters.GetObjects)
 			if err != nil {
 				return err
 			}
-			return manifests.RenderObjects(objs, os.Stdout)
+			return manifests.RenderObjectsAs(objs, outputFrom(cmd), os.Stdout)
 		},
 		Args: cobra.NoArgs,
 	}
 	return render
 }
 
-func makeUpdaterObjects(commonOpts *options.Options) ([]client.Object, string, error) {
+// makeUpdaterObjects renders the objects of the updater using `getObjects`, which sets their order.
+func makeUpdaterObjects(commonOpts *options.Options, getObjects func(opts options.Updater, updaterType, namespace string) ([]client.Object, error)) ([]client.Object, string, error) {
 	ns, namespace, err := updaters.SetupNamespace(commonOpts.UpdaterType)
 	if err != nil {
 		return nil, namespace, err
@@ -153,63 +221,294 @@ func makeUpdaterObjects(commonOpts *options.Options) ([]client.Object, string, e
 		EnableCRIHooks:      commonOpts.UpdaterCRIHooksEnable,
 		CustomSELinuxPolicy: commonOpts.UpdaterCustomSELinuxPolicy,
 	}
-	objs, err := updaters.GetObjects(opts, commonOpts.UpdaterType, namespace)
+	objs, err := getObjects(opts, commonOpts.UpdaterType, namespace)
 	if err != nil {
 		return nil, namespace, err
 	}
 
-	return append([]client.Object{ns}, objs...), namespace, nil
+	objs = append([]client.Object{ns}, objs...)
+	return manifests.StampObjects(objs, strings.ToLower(commonOpts.UpdaterType), commonOpts.InstallID), namespace, nil
 }
 
-func RenderManifests(env *deployer.Environment, commonOpts *options.Options) error {
+func RenderManifests(env *deployer.Environment, commonOpts *options.Options, output string) error {
+	objs, err := makeManifestObjects(env, commonOpts)
+	if err != nil {
+		return err
+	}
+	return manifests.RenderObjectsAs(objs, output, os.Stdout)
+}
+
+// RenderManifestsToDir writes the manifests in `dir`, one file per object, in the order deploy creates them.
+func RenderManifestsToDir(env *deployer.Environment, commonOpts *options.Options, dir string) error {
+	comps, err := makeManifestComponents(env, commonOpts, true)
+	if err != nil {
+		return err
+	}
 	var objs []client.Object
+	for _, comp := range comps {
+		objs = append(objs, comp.Objects...)
+	}
+	return renderObjectsToDir(env, objs, dir)
+}
+
+func renderObjectsToDir(env *deployer.Environment, objs []client.Object, dir string) error {
+	idx, err := manifests.RenderObjectsToDir(objs, dir)
+	if err != nil {
+		return err
+	}
+	env.Log.Info("rendered manifests", "directory", dir, "objects", len(idx.Objects))
+	return nil
+}
+
+func outputDirFrom(cmd *cobra.Command) string {
+	dir, err := cmd.Flags().GetString("output-dir")
+	if err != nil {
+		return ""
+	}
+	return dir
+}
+
+func outputFrom(cmd *cobra.Command) string {
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return manifests.OutputYAML
+	}
+	return output
+}
+
+// requireYAMLOutput fails if --output was changed, because `what` always writes YAML files.
+func requireYAMLOutput(cmd *cobra.Command, what string) error {
+	if output := outputFrom(cmd); output != manifests.OutputYAML {
+		return fmt.Errorf("--output=%s is not supported with %s", output, what)
+	}
+	return nil
+}
+
+func waitableObjects(wos []objectwait.WaitableObject) []client.Object {
+	objs := make([]client.Object, 0, len(wos))
+	for _, wo := range wos {
+		objs = append(objs, wo.Obj)
+	}
+	return objs
+}
+
+// RenderKustomize writes the manifests as a kustomize tree in `dir`. Without overlays, the tree
+// is a base with one directory per component. Otherwise the objects rendered identically for all
+// the platforms go in the base, and each platform gets an overlay with the objects specific to it.
+func RenderKustomize(env *deployer.Environment, commonOpts *options.Options, dir string, overlaySpecs []string) error {
+	comps, err := makeManifestComponents(env, commonOpts, false)
+	if err != nil {
+		return err
+	}
+	if len(overlaySpecs) == 0 {
+		tree, err := kustomize.Base(comps)
+		if err != nil {
+			return err
+		}
+		return tree.Write(dir)
+	}
+
+	overlays := []kustomize.Overlay{
+		{
+			Name:       overlayName(commonOpts.UserPlatform),
+			Components: comps,
+		},
+	}
+	for _, spec := range overlaySpecs {
+		plat, ver, err := parsePlatformSpec(spec)
+		if err != nil {
+			return err
+		}
+		if plat == platform.Unknown {
+			return fmt.Errorf("unsupported overlay platform: %q", spec)
+		}
+		name := overlayName(plat)
+		for _, ov := range overlays {
+			if ov.Name == name {
+				return fmt.Errorf("duplicate overlay for platform %s", plat)
+			}
+		}
+		platOpts := *commonOpts
+		platOpts.UserPlatform = plat
+		platOpts.UserPlatformVersion = ver
+		platComps, err := makeManifestComponents(env, &platOpts, false)
+		if err != nil {
+			return err
+		}
+		overlays = append(overlays, kustomize.Overlay{
+			Name:       name,
+			Components: platComps,
+		})
+	}
+	tree, err := kustomize.WithOverlays(overlays)
+	if err != nil {
+		return err
+	}
+	return tree.Write(dir)
+}
+
+func overlayName(plat platform.Platform) string {
+	return strings.ToLower(plat.String())
+}
+
+func makeManifestObjects(env *deployer.Environment, commonOpts *options.Options) ([]client.Object, error) {
+	comps, err := makeManifestComponents(env, commonOpts, false)
+	if err != nil {
+		return nil, err
+	}
+	var objs []client.Object
+	for _, comp := range comps {
+		objs = append(objs, comp.Objects...)
+	}
+	return objs, nil
+}
+
+// makeManifestComponents renders the objects of all the components. If `creationOrder` is set, the objects
+// are in the order deploy creates them, otherwise in the order they are rendered on stdout.
+// renderSchedulerOptionsFrom returns the scheduler options to render the manifests, which use
+// the platform and version given by the user, as render does not detect them.
+func renderSchedulerOptionsFrom(commonOpts *options.Options) options.Scheduler {
+	opts := deploy.SchedulerOptionsFrom(commonOpts)
+	opts.Platform = commonOpts.UserPlatform
+	opts.PlatformVersion = commonOpts.UserPlatformVersion
+	return opts
+}
+
+func makeManifestComponents(env *deployer.Environment, commonOpts *options.Options, creationOrder bool) ([]kustomize.Component, error) {
+	getUpdaterObjects := updaters.GetObjects
+	if creationOrder {
+		getUpdaterObjects = updaters.GetCreatableObjects
+	}
 
 	apiManifests, err := apimanifests.NewWithOptions(options.Render{
 		Platform: commonOpts.UserPlatform,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 	apiObjs, err := apiManifests.Render()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	objs = append(objs, apiObjs.ToObjects()...)
 
-	updaterObjs, updaterNs, err := makeUpdaterObjects(commonOpts)
+	updaterObjs, updaterNs, err := makeUpdaterObjects(commonOpts, getUpdaterObjects)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	objs = append(objs, updaterObjs...)
 
 	schedManifests, err := schedmanifests.NewWithOptions(options.Render{
 		Platform:  commonOpts.UserPlatform,
 		Namespace: updaterNs,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	schedRenderOpts := options.Scheduler{
-		Replicas:               int32(commonOpts.Replicas),
-		PullIfNotPresent:       commonOpts.PullIfNotPresent,
-		ProfileName:            commonOpts.SchedProfileName,
-		CacheResyncPeriod:      commonOpts.SchedResyncPeriod,
-		CtrlPlaneAffinity:      commonOpts.SchedCtrlPlaneAffinity,
-		Verbose:                commonOpts.SchedVerbose,
-		ScoringStratConfigData: commonOpts.SchedScoringStratConfigData,
-		CacheParamsConfigData:  commonOpts.SchedCacheParamsConfigData,
-		LeaderElection:         commonOpts.Replicas > 1,
-		LeaderElectionResource: commonOpts.SchedLeaderElectResource,
-	}
+	schedRenderOpts := renderSchedulerOptionsFrom(commonOpts)
 
 	schedObjs, err := schedManifests.Render(env.Log, schedRenderOpts)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	apiObjList, schedObjList := apiObjs.ToObjects(), schedObjs.ToObjects()
+	if creationOrder {
+		apiObjList = waitableObjects(apiwait.Creatable(apiObjs, env.Cli, env.Log))
+		schedObjList = waitableObjects(schedwait.Creatable(schedObjs, env.Cli, env.Log))
 	}
-	objs = append(objs, schedObjs.ToObjects()...)
+	return []kustomize.Component{
+		{
+			Name:    manifests.ComponentAPI,
+			Objects: manifests.StampObjects(apiObjList, manifests.ComponentAPI, commonOpts.InstallID),
+		},
+		{
+			Name:    strings.ToLower(commonOpts.UpdaterType),
+			Objects: updaterObjs,
+		},
+		{
+			Name:    manifests.ComponentSchedulerPlugin,
+			Objects: manifests.StampObjects(schedObjList, manifests.ComponentSchedulerPlugin, commonOpts.InstallID),
+		},
+	}, nil
+}
 
-	return manifests.RenderObjects(objs, os.Stdout)
+func NewRenderHelmChartCommand(env *deployer.Environment, commonOpts *options.Options) *cobra.Command {
+	var chartVersion string
+	render := &cobra.Command{
+		Use:   "helm-chart",
+		Short: "render a helm chart for topology-aware-scheduling",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if commonOpts.UserPlatform == platform.Unknown {
+				return fmt.Errorf("must explicitly select a cluster platform")
+			}
+			outputDir := outputDirFrom(cmd)
+			if outputDir == "" {
+				return fmt.Errorf("missing --output-dir")
+			}
+			if err := requireYAMLOutput(cmd, "helm-chart"); err != nil {
+				return err
+			}
+			return RenderHelmChart(env, commonOpts, outputDir, chartVersion)
+		},
+		Args: cobra.NoArgs,
+	}
+	render.Flags().StringVar(&chartVersion, "chart-version", "", "version of the chart. Defaults to the deployer version.")
+	return render
+}
+
+const (
+	helmToggleNotification   = "notification"
+	helmToggleLeaderElection = "leaderElection"
+)
+
+// RenderHelmChart writes in `dir` a helm chart whose values template the knobs
+// of the deployer, with the current options as defaults.
+func RenderHelmChart(env *deployer.Environment, commonOpts *options.Options, dir, chartVersion string) error {
+	if chartVersion == "" {
+		chartVersion = "0.0.0"
+		if ver, err := k8sversion.ParseSemantic(manifests.DeployerVersion); err == nil {
+			chartVersion = ver.String()
+		}
+	}
+	meta := helm.Metadata{
+		Name:        helm.ChartName,
+		Version:     chartVersion,
+		AppVersion:  manifests.DeployerVersion,
+		Description: fmt.Sprintf("topology-aware-scheduling stack for %s", commonOpts.UserPlatform),
+		Type:        "application",
+	}
+
+	// the scheduler plugin enables leader election when running more than 1 replica
+	toggles := []helm.Toggle{
+		{
+			Name:      helmToggleLeaderElection,
+			Condition: "gt (int .Values.scheduler.replicas) 1",
+			Default:   commonOpts.Replicas > 1,
+		},
+	}
+	if commonOpts.UpdaterType == updaters.RTE {
+		toggles = append(toggles, helm.Toggle{
+			Name:        helmToggleNotification,
+			Condition:   ".Values." + helm.ValueUpdaterNotifEnable,
+			Value:       helm.ValueUpdaterNotifEnable,
+			Description: "make the topology updater react to the notifications of the container runtime hooks",
+			Default:     commonOpts.UpdaterNotifEnable,
+		})
+	}
+
+	tree, err := helm.Generate(meta, toggles, func(state map[string]bool) ([]kustomize.Component, error) {
+		opts := *commonOpts
+		opts.UpdaterNotifEnable = state[helmToggleNotification]
+		if state[helmToggleLeaderElection] && opts.Replicas <= 1 {
+			opts.Replicas = 2
+		} else if !state[helmToggleLeaderElection] {
+			opts.Replicas = 1
+		}
+		return makeManifestComponents(env, &opts, false)
+	})
+	if err != nil {
+		return err
+	}
+	return tree.Write(dir)
 }
 
 func NewRenderPolicyCommand(env *deployer.Environment, commonOpts *options.Options, opts *options.Scheduler) *cobra.Command {
@@ -220,6 +519,9 @@ func NewRenderPolicyCommand(env *deployer.Environment, commonOpts *options.Optio
 			if commonOpts.UserPlatform != platform.OpenShift {
 				return fmt.Errorf("must explicitly select the OpenShift platform")
 			}
+			if outputDirFrom(cmd) != "" {
+				return fmt.Errorf("--output-dir is not supported when rendering the policy")
+			}
 			selinuxPolicy, err := selinuxassets.GetPolicy(commonOpts.UserPlatformVersion)
 			if err != nil {
 				return err
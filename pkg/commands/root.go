@@ -19,6 +19,7 @@ package commands
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,11 +27,15 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/config"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform/detect"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/updaters"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/wait"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/inventory"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
 	schedmanifests "github.com/k8stopologyawareschedwg/deployer/pkg/manifests/sched"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
@@ -42,8 +47,14 @@ type internalOptions struct {
 	rteConfigFile               string
 	schedScoringStratConfigFile string
 	schedCacheParamsConfigFile  string
+	schedProfiles               []string
+	schedPlugins                []string
+	schedPodConfigFile          string
+	schedCtrlPodConfigFile      string
+	updaterPodConfigFile        string
 	updaterSCCVersion           string
 	plat                        string
+	configFile                  string
 }
 
 func ShowHelp(cmd *cobra.Command, args []string) error {
@@ -63,6 +74,9 @@ func NewRootCommand(env *deployer.Environment, extraCmds ...NewCommandFunc) *cob
 		Short: "deployer helps setting up all the topology-aware-scheduling components on a kubernetes cluster",
 
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := ApplyConfigFile(env, cmd.Flags(), &commonOpts, &internalOpts); err != nil {
+				return err
+			}
 			return PostSetupOptions(env, &commonOpts, &internalOpts)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -79,6 +93,9 @@ func NewRootCommand(env *deployer.Environment, extraCmds ...NewCommandFunc) *cob
 		NewValidateCommand(env, &commonOpts),
 		NewDeployCommand(env, &commonOpts),
 		NewRemoveCommand(env, &commonOpts),
+		NewUpgradeCommand(env, &commonOpts),
+		NewDiffCommand(env, &commonOpts),
+		NewStatusCommand(env, &commonOpts),
 		NewSetupCommand(env, &commonOpts),
 		NewDetectCommand(env, &commonOpts),
 		NewImagesCommand(env, &commonOpts),
@@ -91,11 +108,15 @@ func NewRootCommand(env *deployer.Environment, extraCmds ...NewCommandFunc) *cob
 }
 
 func InitFlags(flags *pflag.FlagSet, commonOpts *options.Options, internalOpts *internalOptions) {
+	flags.StringVar(&internalOpts.configFile, "config", "", "read the configuration from this file. Flags set on the command line take precedence.")
 	flags.IntVarP(&internalOpts.verbose, "verbose", "v", 1, "set the tool verbosity.")
 	flags.StringVarP(&internalOpts.plat, "platform", "P", "", "platform kind:version to deploy on (example kubernetes:v1.22)")
 	flags.StringVar(&internalOpts.rteConfigFile, "rte-config-file", "", "inject rte configuration reading from this file.")
 	flags.StringVar(&internalOpts.schedScoringStratConfigFile, "sched-scoring-strat-config-file", "", "inject scheduler scoring strategy configuration reading from this file.")
 	flags.StringVar(&internalOpts.schedCacheParamsConfigFile, "sched-cache-params-config-file", "", "inject scheduler fine cache params configuration reading from this file.")
+	flags.StringVar(&internalOpts.updaterPodConfigFile, "updater-pod-config-file", "", "tune the resources and the placement of the updater pods reading from this file.")
+	flags.StringVar(&internalOpts.schedPodConfigFile, "sched-pod-config-file", "", "tune the resources and the placement of the scheduler pods reading from this file.")
+	flags.StringVar(&internalOpts.schedCtrlPodConfigFile, "sched-ctrl-pod-config-file", "", "tune the resources and the placement of the scheduler controller pods reading from this file.")
 	flags.IntVarP(&internalOpts.replicas, "replicas", "R", 1, "set the replica value - where relevant.")
 	flags.StringVar(&internalOpts.updaterSCCVersion, "updater-scc", "v2", "select the SecurityContextConstraint version to use. v2 by default")
 
@@ -110,10 +131,17 @@ func InitFlags(flags *pflag.FlagSet, commonOpts *options.Options, internalOpts *
 	flags.DurationVar(&commonOpts.UpdaterSyncPeriod, "updater-sync-period", manifests.DefaultUpdaterSyncPeriod, "tune the updater synchronization (nrt update) interval. Use 0 to disable.")
 	flags.IntVar(&commonOpts.UpdaterVerbose, "updater-verbose", manifests.DefaultUpdaterVerbose, "set the updater verbosiness.")
 	flags.StringVar(&commonOpts.SchedProfileName, "sched-profile-name", schedmanifests.DefaultProfileName, "inject scheduler profile name.")
+	flags.StringArrayVar(&internalOpts.schedProfiles, "sched-profile", nil, "add a scheduler profile: \"name=<name>[,scoring-strat-config-file=<path>][,cache-params-config-file=<path>][,preemption-mode=<mode>]\". The configuration files not given are inherited from the scheduler-wide ones. Can be repeated.")
 	flags.DurationVar(&commonOpts.SchedResyncPeriod, "sched-resync-period", schedmanifests.DefaultResyncPeriod, "inject scheduler resync period.")
 	flags.IntVar(&commonOpts.SchedVerbose, "sched-verbose", schedmanifests.DefaultVerbose, "set the scheduler verbosiness.")
 	flags.BoolVar(&commonOpts.SchedCtrlPlaneAffinity, "sched-ctrlplane-affinity", schedmanifests.DefaultCtrlPlaneAffinity, "toggle the scheduler control plane affinity.")
 	flags.StringVar(&commonOpts.SchedLeaderElectResource, "sched-leader-elect-resource", schedmanifests.DefaultLeaderElectResource, "leader election resource namespaced name \"namespace/name\"")
+	flags.StringArrayVar(&internalOpts.schedPlugins, "sched-plugin", nil, "change a plugin of the scheduler profiles: \"name=<name>[,enable=<extension point>][,disable=<extension point>][,weight=<weight>][,args-file=<path>]\". enable and disable can be repeated. Profiles which set their own plugins in the configuration file are not changed. Can be repeated.")
+	flags.StringVar(&commonOpts.SchedPreemptionMode, "sched-preemption-mode", "", "set the scheduler preemption mode, \""+manifests.PreemptionEnabled+"\" or \""+manifests.PreemptionDisabled+"\". Leave empty to use the scheduler default.")
+	flags.StringVar(&commonOpts.SchedInformerMode, "sched-informer-mode", "", "set the scheduler cache informer mode, \""+manifests.CacheInformerShared+"\" or \""+manifests.CacheInformerDedicated+"\". Leave empty to use the scheduler default.")
+	flags.StringVar(&commonOpts.SchedConfigAPIVersion, "sched-config-api-version", manifests.SchedulerConfigVersionAuto, "API version of the scheduler configuration: \""+manifests.SchedulerConfigVersionAuto+"\" picks the most recent supported by the platform version, or \""+manifests.SchedulerConfigVersionV1beta3+"\", \""+manifests.SchedulerConfigVersionV1+"\".")
+	flags.StringVar(&commonOpts.InstallID, "install-id", manifests.DefaultInstallID, "identifier of the installation, recorded in the labels of all the objects.")
+	flags.StringVar(&commonOpts.InventoryNamespace, "inventory-namespace", inventory.DefaultNamespace, "namespace of the inventory recording the installed objects.")
 }
 
 func PostSetupOptions(env *deployer.Environment, commonOpts *options.Options, internalOpts *internalOptions) error {
@@ -127,6 +155,36 @@ func PostSetupOptions(env *deployer.Environment, commonOpts *options.Options, in
 	}
 	commonOpts.UpdaterSCCVersion = options.SCCVersion(internalOpts.updaterSCCVersion)
 
+	if errs := validation.IsDNS1123Label(commonOpts.InstallID); len(errs) > 0 {
+		return fmt.Errorf("install ID %q is invalid: %s", commonOpts.InstallID, strings.Join(errs, "; "))
+	}
+
+	if commonOpts.SchedPreemptionMode != "" {
+		if err := manifests.ValidatePreemptionMode(commonOpts.SchedPreemptionMode); err != nil {
+			return err
+		}
+	}
+	if commonOpts.SchedInformerMode != "" {
+		if err := manifests.ValidateCacheInformerMode(commonOpts.SchedInformerMode); err != nil {
+			return err
+		}
+	}
+
+	if err := manifests.ValidateSchedulerConfigVersion(commonOpts.SchedConfigAPIVersion); err != nil {
+		return err
+	}
+
+	if commonOpts.Parallelism < 0 {
+		return fmt.Errorf("parallelism %d is invalid", commonOpts.Parallelism)
+	}
+
+	if !options.IsValidDryRun(commonOpts.DryRun) {
+		return fmt.Errorf("dry-run mode %q is invalid", commonOpts.DryRun)
+	}
+	if commonOpts.DryRun == options.DryRunServer {
+		*env = *env.WithDryRun()
+	}
+
 	if internalOpts.replicas < 0 {
 		err := env.EnsureClient()
 		if err != nil {
@@ -149,14 +207,12 @@ func PostSetupOptions(env *deployer.Environment, commonOpts *options.Options, in
 		commonOpts.UserPlatform = platform.Unknown
 		commonOpts.UserPlatformVersion = platform.MissingVersion
 	} else {
-		fields := strings.FieldsFunc(internalOpts.plat, func(c rune) bool {
-			return c == ':'
-		})
-		if len(fields) != 2 {
-			return fmt.Errorf("unsupported platform spec: %q", internalOpts.plat)
+		plat, ver, err := parsePlatformSpec(internalOpts.plat)
+		if err != nil {
+			return err
 		}
-		commonOpts.UserPlatform, _ = platform.ParsePlatform(fields[0])
-		commonOpts.UserPlatformVersion, _ = platform.ParseVersion(fields[1])
+		commonOpts.UserPlatform = plat
+		commonOpts.UserPlatformVersion = ver
 	}
 
 	if internalOpts.rteConfigFile != "" {
@@ -183,6 +239,52 @@ func PostSetupOptions(env *deployer.Environment, commonOpts *options.Options, in
 		commonOpts.SchedCacheParamsConfigData = string(data)
 		env.Log.Info("Scheduler Cache Parameters config: read", "bytes", len(commonOpts.SchedCacheParamsConfigData))
 	}
+	if internalOpts.updaterPodConfigFile != "" {
+		podSettings, err := readPodSettings(internalOpts.updaterPodConfigFile)
+		if err != nil {
+			return err
+		}
+		commonOpts.UpdaterPodSettings = podSettings
+		env.Log.Info("Updater pod config: read", "path", internalOpts.updaterPodConfigFile)
+	}
+	if internalOpts.schedPodConfigFile != "" {
+		podSettings, err := readPodSettings(internalOpts.schedPodConfigFile)
+		if err != nil {
+			return err
+		}
+		commonOpts.SchedPodSettings = podSettings
+		env.Log.Info("Scheduler pod config: read", "path", internalOpts.schedPodConfigFile)
+	}
+	if internalOpts.schedCtrlPodConfigFile != "" {
+		podSettings, err := readPodSettings(internalOpts.schedCtrlPodConfigFile)
+		if err != nil {
+			return err
+		}
+		commonOpts.SchedCtrlPodSettings = podSettings
+		env.Log.Info("Scheduler controller pod config: read", "path", internalOpts.schedCtrlPodConfigFile)
+	}
+	if len(internalOpts.schedProfiles) > 0 {
+		commonOpts.SchedProfiles = nil
+		for _, spec := range internalOpts.schedProfiles {
+			prof, err := parseSchedProfileSpec(spec)
+			if err != nil {
+				return err
+			}
+			commonOpts.SchedProfiles = append(commonOpts.SchedProfiles, prof)
+		}
+		env.Log.Info("Scheduler profiles: read", "count", len(commonOpts.SchedProfiles))
+	}
+	if len(internalOpts.schedPlugins) > 0 {
+		commonOpts.SchedPlugins = nil
+		for _, spec := range internalOpts.schedPlugins {
+			plugin, err := parseSchedPluginSpec(spec)
+			if err != nil {
+				return err
+			}
+			commonOpts.SchedPlugins = append(commonOpts.SchedPlugins, plugin)
+		}
+		env.Log.Info("Scheduler plugins: read", "count", len(commonOpts.SchedPlugins))
+	}
 
 	return validateUpdaterType(commonOpts.UpdaterType)
 }
@@ -193,3 +295,106 @@ func validateUpdaterType(updaterType string) error {
 	}
 	return nil
 }
+
+func readPodSettings(path string) (options.PodSettings, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return options.PodSettings{}, err
+	}
+	podCfg, err := config.DecodePodConfig(data)
+	if err != nil {
+		return options.PodSettings{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return podSettingsFromConfig(podCfg), nil
+}
+
+// parseSchedProfileSpec parses a comma-separated list of key=value pairs describing
+// an additional scheduler profile, reading the configuration files it references.
+func parseSchedProfileSpec(spec string) (options.SchedulerProfile, error) {
+	prof := options.SchedulerProfile{}
+	for _, item := range strings.Split(spec, ",") {
+		key, value, ok := strings.Cut(item, "=")
+		if !ok || value == "" {
+			return prof, fmt.Errorf("malformed scheduler profile %q: expected key=value, got %q", spec, item)
+		}
+		switch key {
+		case "name":
+			prof.Name = value
+		case "scoring-strat-config-file":
+			data, err := os.ReadFile(value)
+			if err != nil {
+				return prof, err
+			}
+			prof.ScoringStratConfigData = string(data)
+		case "cache-params-config-file":
+			data, err := os.ReadFile(value)
+			if err != nil {
+				return prof, err
+			}
+			prof.CacheParamsConfigData = string(data)
+		case "preemption-mode":
+			if err := manifests.ValidatePreemptionMode(value); err != nil {
+				return prof, fmt.Errorf("scheduler profile %q: %w", spec, err)
+			}
+			prof.PreemptionMode = value
+		default:
+			return prof, fmt.Errorf("malformed scheduler profile %q: unknown key %q", spec, key)
+		}
+	}
+	if prof.Name == "" {
+		return prof, fmt.Errorf("malformed scheduler profile %q: missing name", spec)
+	}
+	return prof, nil
+}
+
+// parseSchedPluginSpec parses a comma-separated list of key=value pairs describing
+// the changes to a scheduler plugin, reading the arguments file it references.
+func parseSchedPluginSpec(spec string) (options.SchedulerPlugin, error) {
+	plugin := options.SchedulerPlugin{}
+	for _, item := range strings.Split(spec, ",") {
+		key, value, ok := strings.Cut(item, "=")
+		if !ok || value == "" {
+			return plugin, fmt.Errorf("malformed scheduler plugin %q: expected key=value, got %q", spec, item)
+		}
+		switch key {
+		case "name":
+			plugin.Name = value
+		case "enable":
+			plugin.EnabledAt = append(plugin.EnabledAt, value)
+		case "disable":
+			plugin.DisabledAt = append(plugin.DisabledAt, value)
+		case "weight":
+			weight, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				return plugin, fmt.Errorf("malformed scheduler plugin %q: %w", spec, err)
+			}
+			plugin.Weight = int32(weight)
+		case "args-file":
+			data, err := os.ReadFile(value)
+			if err != nil {
+				return plugin, err
+			}
+			plugin.ArgsData = string(data)
+		default:
+			return plugin, fmt.Errorf("malformed scheduler plugin %q: unknown key %q", spec, key)
+		}
+	}
+	if plugin.Name == "" {
+		return plugin, fmt.Errorf("malformed scheduler plugin %q: missing name", spec)
+	}
+	return plugin, nil
+}
+
+// parsePlatformSpec parses a kind:version platform spec. Unknown kinds and
+// unparseable versions are reported as platform.Unknown and an empty version.
+func parsePlatformSpec(spec string) (platform.Platform, platform.Version, error) {
+	fields := strings.FieldsFunc(spec, func(c rune) bool {
+		return c == ':'
+	})
+	if len(fields) != 2 {
+		return platform.Unknown, platform.MissingVersion, fmt.Errorf("unsupported platform spec: %q", spec)
+	}
+	plat, _ := platform.ParsePlatform(fields[0])
+	ver, _ := platform.ParseVersion(fields[1])
+	return plat, ver, nil
+}
@@ -0,0 +1,144 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/clientutil/nodes"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deploy"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/status"
+)
+
+// nrtMaxAgeFactor is how many updater sync periods a NodeResourceTopology object can miss before being degraded.
+const nrtMaxAgeFactor = 3
+
+type statusOptions struct {
+	jsonOutput bool
+	nrtMaxAge  time.Duration
+}
+
+func NewStatusCommand(env *deployer.Environment, commonOpts *options.Options) *cobra.Command {
+	opts := &statusOptions{}
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "report the health of the installed topology-aware-scheduling components",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("nrt-max-age") {
+				opts.nrtMaxAge = nrtMaxAgeFactor * commonOpts.UpdaterSyncPeriod
+			}
+			return reportStatus(env, commonOpts, opts)
+		},
+		Args: cobra.NoArgs,
+	}
+	statusCmd.Flags().BoolVarP(&opts.jsonOutput, "json", "J", false, "output JSON, not text.")
+	statusCmd.Flags().DurationVar(&opts.nrtMaxAge, "nrt-max-age", nrtMaxAgeFactor*manifests.DefaultUpdaterSyncPeriod, fmt.Sprintf("consider degraded the NodeResourceTopology objects not updated since this long, unless the updater pod on their node is ready. Defaults to %d times the updater sync period. Use 0 to only check presence.", nrtMaxAgeFactor))
+	return statusCmd
+}
+
+func reportStatus(env *deployer.Environment, commonOpts *options.Options, opts *statusOptions) error {
+	if err := env.EnsureClient(); err != nil {
+		return err
+	}
+	if err := deploy.DetectCluster(env, commonOpts); err != nil {
+		return err
+	}
+
+	comps, err := statusComponents(env, commonOpts)
+	if err != nil {
+		return err
+	}
+	workers, err := nodes.GetWorkers(env)
+	if err != nil {
+		return err
+	}
+
+	rep, err := status.Collect(env, comps, status.Options{
+		Workers:   workers,
+		NRTMaxAge: opts.nrtMaxAge,
+		Now:       time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.jsonOutput {
+		if err := json.NewEncoder(os.Stdout).Encode(rep); err != nil {
+			return err
+		}
+	} else {
+		for _, it := range rep.Items {
+			fmt.Printf("%s\n", it.String())
+		}
+	}
+
+	if degraded := rep.Degraded(); len(degraded) > 0 {
+		return fmt.Errorf("topology-aware-scheduling degraded: %d/%d checks failed", len(degraded), len(rep.Items))
+	}
+	return nil
+}
+
+// statusComponents returns the components recorded in the inventory, if any,
+// or the components rendered from the options otherwise.
+func statusComponents(env *deployer.Environment, commonOpts *options.Options) ([]status.Component, error) {
+	inv, err := deploy.LoadInventory(env, commonOpts)
+	if err == nil {
+		env.Log.V(3).Info("checking the inventory components", "installID", inv.InstallID)
+		var comps []status.Component
+		for _, comp := range inv.Components {
+			objs, err := comp.ToObjects(env.Cli.Scheme())
+			if err != nil {
+				return nil, err
+			}
+			comps = append(comps, status.Component{Name: comp.Name, Objects: objs})
+		}
+		return comps, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	env.Log.V(3).Info("inventory not found, checking the rendered components")
+	apiObjs, err := deploy.APIObjects(commonOpts)
+	if err != nil {
+		return nil, err
+	}
+	updaterObjs, err := deploy.UpdaterObjects(commonOpts, commonOpts.UpdaterType)
+	if err != nil {
+		return nil, err
+	}
+	schedObjs, err := deploy.SchedulerObjects(env, commonOpts)
+	if err != nil {
+		return nil, err
+	}
+	return []status.Component{
+		{Name: manifests.ComponentAPI, Objects: apiObjs},
+		{Name: strings.ToLower(commonOpts.UpdaterType), Objects: updaterObjs},
+		{Name: manifests.ComponentSchedulerPlugin, Objects: schedObjs},
+	}, nil
+}
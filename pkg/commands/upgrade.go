@@ -0,0 +1,61 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deploy"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+)
+
+type upgradeOptions struct {
+	planOnly bool
+}
+
+func NewUpgradeCommand(env *deployer.Environment, commonOpts *options.Options) *cobra.Command {
+	opts := &upgradeOptions{}
+	upgrade := &cobra.Command{
+		Use:   "upgrade",
+		Short: "upgrade an installed topology-aware-scheduling stack to the manifests this deployer ships",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plan, err := deploy.PlanUpgrade(env, commonOpts)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stdout, "platform: %s %s\n", plan.Platform, plan.Version)
+			fmt.Fprintf(os.Stdout, "installed: api=%v updater=%q scheduler=%v\n", plan.API, plan.UpdaterType, plan.Scheduler)
+			if plan.IsEmpty() {
+				fmt.Fprintf(os.Stdout, "no changes needed\n")
+			}
+			for _, change := range plan.Changes {
+				fmt.Fprintf(os.Stdout, "%s\n", change.String())
+			}
+			if opts.planOnly {
+				return nil
+			}
+			return deploy.Upgrade(env, commonOpts, plan)
+		},
+		Args: cobra.NoArgs,
+	}
+	upgrade.Flags().BoolVar(&opts.planOnly, "plan", false, "print the upgrade plan and exit without changing the cluster.")
+	return upgrade
+}
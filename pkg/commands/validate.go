@@ -20,12 +20,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 
 	"github.com/k8stopologyawareschedwg/deployer/pkg/clientutil/nodes"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform/detect"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/kubeletconfig"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/validator"
 )
@@ -40,8 +44,16 @@ const (
 )
 
 type validateOptions struct {
-	outputMode ValidateOutputMode
-	jsonOutput bool
+	outputMode      ValidateOutputMode
+	jsonOutput      bool
+	nodeConcurrency int
+	nodeTimeout     time.Duration
+	fromDir         string
+	serverVersion   string
+	enableRules     []string
+	disableRules    []string
+	ruleSeverities  map[string]string
+	listRules       bool
 }
 
 func NewValidateCommand(env *deployer.Environment, commonOpts *options.Options) *cobra.Command {
@@ -55,6 +67,14 @@ func NewValidateCommand(env *deployer.Environment, commonOpts *options.Options)
 		Args: cobra.NoArgs,
 	}
 	validate.Flags().BoolVarP(&opts.jsonOutput, "json", "J", false, "output JSON, not text.")
+	validate.Flags().IntVar(&opts.nodeConcurrency, "node-concurrency", kubeletconfig.DefaultConcurrency, "maximum number of nodes queried at the same time.")
+	validate.Flags().DurationVar(&opts.nodeTimeout, "node-timeout", kubeletconfig.DefaultNodeTimeout, "timeout to fetch the configuration of each node.")
+	validate.Flags().StringVar(&opts.fromDir, "from-dir", "", "validate offline the kubelet configuration files (or configz dumps) in this directory, named after the nodes.")
+	validate.Flags().StringVar(&opts.serverVersion, "server-version", "", "server version to validate offline. Requires --from-dir.")
+	validate.Flags().StringSliceVar(&opts.enableRules, "enable", nil, "run only these validation rules. Use --list-rules to see all the rules.")
+	validate.Flags().StringSliceVar(&opts.disableRules, "disable", nil, "skip these validation rules.")
+	validate.Flags().StringToStringVar(&opts.ruleSeverities, "severity", nil, "override the severity (error, warning, info) of the rules, as RULE=SEVERITY.")
+	validate.Flags().BoolVar(&opts.listRules, "list-rules", false, "list the validation rules and exit.")
 	return validate
 }
 
@@ -78,7 +98,24 @@ func validateCluster(cmd *cobra.Command, env *deployer.Environment, commonOpts *
 	// TODO
 	validatePostSetupOptions(opts)
 
-	err := env.EnsureClient()
+	if opts.listRules {
+		printValidationRules(validator.DefaultRegistry().Rules())
+		return nil
+	}
+
+	rules, err := selectValidationRules(opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.fromDir != "" {
+		return validateFromDir(env, commonOpts, opts, rules)
+	}
+	if opts.serverVersion != "" {
+		return fmt.Errorf("--server-version requires --from-dir")
+	}
+
+	err = env.EnsureClient()
 	if err != nil {
 		return err
 	}
@@ -87,6 +124,17 @@ func validateCluster(cmd *cobra.Command, env *deployer.Environment, commonOpts *
 	if err != nil {
 		return err
 	}
+	vd.Concurrency = opts.nodeConcurrency
+	vd.NodeTimeout = opts.nodeTimeout
+	vd.Rules = rules
+
+	platDetect, reason, err := detect.FindPlatform(env.Ctx, commonOpts.UserPlatform)
+	if err != nil {
+		// the rules restricted to some platforms are run anyway
+		env.Log.Info("cannot autodetect the platform", "error", err)
+	}
+	vd.Platform = platDetect.Discovered
+	env.Log.V(3).Info("detection", "platform", vd.Platform, "reason", reason)
 
 	nodeList, err := nodes.GetWorkers(env)
 	if err != nil {
@@ -101,42 +149,64 @@ func validateCluster(cmd *cobra.Command, env *deployer.Environment, commonOpts *
 	return nil
 }
 
+func validateFromDir(env *deployer.Environment, commonOpts *options.Options, opts *validateOptions, rules *validator.RuleSet) error {
+	kubeletConfs, err := kubeletconfig.ReadKubeletConfigsFromDir(opts.fromDir)
+	if err != nil {
+		return err
+	}
+	printValidationResults(rules.ValidateKubeletConfigs(commonOpts.UserPlatform, opts.serverVersion, kubeletConfs), env.Log, opts.outputMode)
+	return nil
+}
+
+func selectValidationRules(opts *validateOptions) (*validator.RuleSet, error) {
+	sel := validator.Selection{
+		Enable:     opts.enableRules,
+		Disable:    opts.disableRules,
+		Severities: make(map[string]validator.Severity, len(opts.ruleSeverities)),
+	}
+	for id, sev := range opts.ruleSeverities {
+		severity, err := validator.ParseSeverity(sev)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", id, err)
+		}
+		sel.Severities[id] = severity
+	}
+	return validator.DefaultRegistry().Select(sel)
+}
+
+func printValidationRules(rules []validator.Rule) {
+	for _, rule := range rules {
+		info := rule.Info()
+		fmt.Printf("%-36s %-8s %-8s %s\n", info.ID, info.Severity, info.Scope, info.Description)
+	}
+}
+
 // we need undecorated output, so we need to use fmt.Printf here. log packages add no value.
 func printValidationResults(items []validator.ValidationResult, logger logr.Logger, outputMode ValidateOutputMode) {
-	if len(items) == 0 {
-		switch outputMode {
-		case ValidateOutputJSON:
-			json.NewEncoder(os.Stdout).Encode(validationOutput{
-				Success: true,
-			})
-		case ValidateOutputText:
+	success := !validator.HasErrors(items)
+	switch outputMode {
+	case ValidateOutputJSON:
+		json.NewEncoder(os.Stdout).Encode(validationOutput{
+			Success: success,
+			Errors:  items,
+		})
+	case ValidateOutputText:
+		for idx, item := range items {
+			fmt.Printf("%s#%03d: %s\n", strings.ToUpper(string(item.Severity)), idx, item.String())
+		}
+		if success {
 			fmt.Printf("PASSED>>: the cluster configuration looks ok!\n")
-		case ValidateOutputLog:
-			logger.Info("cluster configuration", "issue", "none")
-		case ValidateOutputNone:
-			fallthrough
-		default:
-			// do nothing!
 		}
-	} else {
-		switch outputMode {
-		case ValidateOutputJSON:
-			json.NewEncoder(os.Stdout).Encode(validationOutput{
-				Success: false,
-				Errors:  items,
-			})
-		case ValidateOutputText:
-			for idx, item := range items {
-				fmt.Printf("ERROR#%03d: %s\n", idx, item.String())
-			}
-		case ValidateOutputLog:
-			for idx, item := range items {
-				logger.Info("cluster configuration", "issue", idx, "description", item.String())
-			}
-		case ValidateOutputNone:
-			fallthrough
-		default:
-			// do nothing!
+	case ValidateOutputLog:
+		for idx, item := range items {
+			logger.Info("cluster configuration", "issue", idx, "severity", item.Severity, "rule", item.Rule, "description", item.String())
+		}
+		if len(items) == 0 {
+			logger.Info("cluster configuration", "issue", "none")
 		}
+	case ValidateOutputNone:
+		fallthrough
+	default:
+		// do nothing!
 	}
 }
@@ -0,0 +1,194 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	APIVersion = "deployer.topology.node.k8s.io/v1alpha1"
+	Kind       = "DeployerConfiguration"
+)
+
+// Config is the declarative configuration of the deployer.
+// All the fields are optional; unset fields don't override anything.
+type Config struct {
+	APIVersion         string           `json:"apiVersion"`
+	Kind               string           `json:"kind"`
+	Verbose            *int             `json:"verbose,omitempty"`
+	Platform           string           `json:"platform,omitempty"`
+	Replicas           *int             `json:"replicas,omitempty"`
+	PullIfNotPresent   *bool            `json:"pullIfNotPresent,omitempty"`
+	Apply              *bool            `json:"apply,omitempty"`
+	Transactional      *bool            `json:"transactional,omitempty"`
+	Parallelism        *int             `json:"parallelism,omitempty"`
+	InstallID          string           `json:"installID,omitempty"`
+	InventoryNamespace string           `json:"inventoryNamespace,omitempty"`
+	Wait               *WaitConfig      `json:"wait,omitempty"`
+	Updater            *UpdaterConfig   `json:"updater,omitempty"`
+	Scheduler          *SchedulerConfig `json:"scheduler,omitempty"`
+}
+
+type WaitConfig struct {
+	Completion *bool            `json:"completion,omitempty"`
+	Interval   *metav1.Duration `json:"interval,omitempty"`
+	Timeout    *metav1.Duration `json:"timeout,omitempty"`
+}
+
+type UpdaterConfig struct {
+	Type                string           `json:"type,omitempty"`
+	PFPEnable           *bool            `json:"pfpEnable,omitempty"`
+	NotifEnable         *bool            `json:"notifEnable,omitempty"`
+	CRIHooksEnable      *bool            `json:"criHooksEnable,omitempty"`
+	CustomSELinuxPolicy *bool            `json:"customSELinuxPolicy,omitempty"`
+	SCCVersion          string           `json:"sccVersion,omitempty"`
+	SyncPeriod          *metav1.Duration `json:"syncPeriod,omitempty"`
+	Verbose             *int             `json:"verbose,omitempty"`
+	// Config is the RTE configuration, like the content of --rte-config-file
+	Config InlineData `json:"config,omitempty"`
+	// Pod is like the content of --updater-pod-config-file
+	Pod *PodConfig `json:"pod,omitempty"`
+}
+
+type SchedulerConfig struct {
+	ProfileName         string           `json:"profileName,omitempty"`
+	ResyncPeriod        *metav1.Duration `json:"resyncPeriod,omitempty"`
+	Verbose             *int             `json:"verbose,omitempty"`
+	CtrlPlaneAffinity   *bool            `json:"ctrlPlaneAffinity,omitempty"`
+	LeaderElectResource string           `json:"leaderElectResource,omitempty"`
+	PreemptionMode      string           `json:"preemptionMode,omitempty"`
+	InformerMode        string           `json:"informerMode,omitempty"`
+	ConfigAPIVersion    string           `json:"configAPIVersion,omitempty"`
+	// ScoringStrategy is like the content of --sched-scoring-strat-config-file
+	ScoringStrategy InlineData `json:"scoringStrategy,omitempty"`
+	// CacheParams is like the content of --sched-cache-params-config-file
+	CacheParams InlineData `json:"cacheParams,omitempty"`
+	// Profiles are the additional scheduler profiles, like --sched-profile
+	Profiles []SchedulerProfileConfig `json:"profiles,omitempty"`
+	// Plugins change the plugins of the profiles which don't set their own, like --sched-plugin
+	Plugins []SchedulerPluginConfig `json:"plugins,omitempty"`
+	// Pod is like the content of --sched-pod-config-file
+	Pod *PodConfig `json:"pod,omitempty"`
+	// ControllerPod is like the content of --sched-ctrl-pod-config-file
+	ControllerPod *PodConfig `json:"controllerPod,omitempty"`
+}
+
+type SchedulerProfileConfig struct {
+	Name            string                  `json:"name"`
+	ScoringStrategy InlineData              `json:"scoringStrategy,omitempty"`
+	CacheParams     InlineData              `json:"cacheParams,omitempty"`
+	PreemptionMode  string                  `json:"preemptionMode,omitempty"`
+	Plugins         []SchedulerPluginConfig `json:"plugins,omitempty"`
+}
+
+type SchedulerPluginConfig struct {
+	Name       string   `json:"name"`
+	EnabledAt  []string `json:"enabledAt,omitempty"`
+	DisabledAt []string `json:"disabledAt,omitempty"`
+	Weight     int32    `json:"weight,omitempty"`
+	// Args are the plugin arguments (pluginConfig)
+	Args InlineData `json:"args,omitempty"`
+}
+
+// PodConfig tunes the resources and the placement of the pods of a workload.
+type PodConfig struct {
+	Resources                 *corev1.ResourceRequirements      `json:"resources,omitempty"`
+	PriorityClassName         string                            `json:"priorityClassName,omitempty"`
+	Tolerations               []corev1.Toleration               `json:"tolerations,omitempty"`
+	NodeAffinity              *corev1.NodeAffinity              `json:"nodeAffinity,omitempty"`
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	PodAntiAffinity           *corev1.PodAntiAffinity           `json:"podAntiAffinity,omitempty"`
+}
+
+// DecodePodConfig decodes a pod configuration document, YAML or JSON, rejecting unknown fields.
+func DecodePodConfig(data []byte) (PodConfig, error) {
+	podCfg := PodConfig{}
+	if err := yaml.UnmarshalStrict(data, &podCfg); err != nil {
+		return podCfg, fmt.Errorf("cannot decode pod configuration: %w", err)
+	}
+	return podCfg, nil
+}
+
+// InlineData holds an embedded configuration document. It can be expressed
+// either as a nested object or as a string holding the document.
+type InlineData json.RawMessage
+
+func (in InlineData) MarshalJSON() ([]byte, error) {
+	if len(in) == 0 {
+		return []byte("null"), nil
+	}
+	return in, nil
+}
+
+func (in *InlineData) UnmarshalJSON(data []byte) error {
+	*in = append((*in)[0:0], data...)
+	return nil
+}
+
+// IsSet tells if the inline data was given.
+func (in InlineData) IsSet() bool {
+	return len(in) > 0 && string(in) != "null"
+}
+
+// ToYAML returns the inline data as YAML document.
+func (in InlineData) ToYAML() (string, error) {
+	if !in.IsSet() {
+		return "", nil
+	}
+	var text string
+	if err := json.Unmarshal(in, &text); err == nil {
+		return text, nil
+	}
+	data, err := yaml.JSONToYAML(in)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Decode decodes a configuration document, YAML or JSON, rejecting unknown fields.
+func Decode(data []byte) (Config, error) {
+	cfg := Config{}
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("cannot decode configuration: %w", err)
+	}
+	if cfg.APIVersion != APIVersion || cfg.Kind != Kind {
+		return cfg, fmt.Errorf("unsupported configuration %s %s: expected %s %s", cfg.APIVersion, cfg.Kind, APIVersion, Kind)
+	}
+	return cfg, nil
+}
+
+// Load reads and decodes the configuration document from the given path.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	cfg, err := Decode(data)
+	if err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,257 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDecode(t *testing.T) {
+	type testCase struct {
+		name        string
+		data        string
+		expectError bool
+		check       func(t *testing.T, cfg Config)
+	}
+
+	testCases := []testCase{
+		{
+			name:        "empty",
+			data:        "",
+			expectError: true,
+		},
+		{
+			name: "wrong kind",
+			data: `apiVersion: deployer.topology.node.k8s.io/v1alpha1
+kind: Foobar
+`,
+			expectError: true,
+		},
+		{
+			name: "unknown field",
+			data: `apiVersion: deployer.topology.node.k8s.io/v1alpha1
+kind: DeployerConfiguration
+updater:
+  tpye: NFD
+`,
+			expectError: true,
+		},
+		{
+			name: "minimal",
+			data: `apiVersion: deployer.topology.node.k8s.io/v1alpha1
+kind: DeployerConfiguration
+`,
+			check: func(t *testing.T, cfg Config) {
+				if cfg.Replicas != nil || cfg.Updater != nil || cfg.Scheduler != nil {
+					t.Errorf("unexpected fields set: %+v", cfg)
+				}
+			},
+		},
+		{
+			name: "full YAML",
+			data: `apiVersion: deployer.topology.node.k8s.io/v1alpha1
+kind: DeployerConfiguration
+platform: kubernetes:v1.30
+replicas: 2
+wait:
+  completion: true
+  timeout: 5m
+updater:
+  type: NFD
+  pfpEnable: false
+  syncPeriod: 30s
+  config:
+    resources:
+      reservedcpus: "0-1"
+scheduler:
+  profileName: tas-scheduler
+  cacheParams: |
+    cache:
+      resyncMethod: Autodetect
+`,
+			check: func(t *testing.T, cfg Config) {
+				if cfg.Platform != "kubernetes:v1.30" || cfg.Replicas == nil || *cfg.Replicas != 2 {
+					t.Errorf("unexpected toplevel fields: %+v", cfg)
+				}
+				if cfg.Wait == nil || cfg.Wait.Completion == nil || !*cfg.Wait.Completion || cfg.Wait.Timeout.Duration != 5*time.Minute {
+					t.Errorf("unexpected wait fields: %+v", cfg.Wait)
+				}
+				if cfg.Updater.Type != "NFD" || cfg.Updater.PFPEnable == nil || *cfg.Updater.PFPEnable || cfg.Updater.SyncPeriod.Duration != 30*time.Second {
+					t.Errorf("unexpected updater fields: %+v", cfg.Updater)
+				}
+				rteConf, err := cfg.Updater.Config.ToYAML()
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if rteConf != "resources:\n  reservedcpus: 0-1\n" {
+					t.Errorf("unexpected RTE config: %q", rteConf)
+				}
+				cacheConf, err := cfg.Scheduler.CacheParams.ToYAML()
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if cacheConf != "cache:\n  resyncMethod: Autodetect\n" {
+					t.Errorf("unexpected cache params config: %q", cacheConf)
+				}
+				if cfg.Scheduler.ScoringStrategy.IsSet() {
+					t.Errorf("unexpected scoring strategy config: %q", string(cfg.Scheduler.ScoringStrategy))
+				}
+			},
+		},
+		{
+			name: "scheduler profiles",
+			data: `apiVersion: deployer.topology.node.k8s.io/v1alpha1
+kind: DeployerConfiguration
+scheduler:
+  profiles:
+  - name: tas-batch
+    preemptionMode: Disabled
+    scoringStrategy:
+      type: MostAllocated
+  - name: tas-latency
+`,
+			check: func(t *testing.T, cfg Config) {
+				profs := cfg.Scheduler.Profiles
+				if len(profs) != 2 || profs[0].Name != "tas-batch" || profs[0].PreemptionMode != "Disabled" || profs[1].Name != "tas-latency" {
+					t.Fatalf("unexpected profiles: %+v", profs)
+				}
+				scoringConf, err := profs[0].ScoringStrategy.ToYAML()
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if scoringConf != "type: MostAllocated\n" {
+					t.Errorf("unexpected scoring strategy config: %q", scoringConf)
+				}
+				if profs[1].ScoringStrategy.IsSet() || profs[1].CacheParams.IsSet() {
+					t.Errorf("unexpected config data: %+v", profs[1])
+				}
+			},
+		},
+		{
+			name: "scheduler plugins",
+			data: `apiVersion: deployer.topology.node.k8s.io/v1alpha1
+kind: DeployerConfiguration
+scheduler:
+  plugins:
+  - name: NodeResourcesFit
+    disabledAt: [score]
+  profiles:
+  - name: tas-batch
+    plugins:
+    - name: Coscheduling
+      enabledAt: [queueSort, permit]
+      weight: 2
+      args:
+        permitWaitingTimeSeconds: 10
+`,
+			check: func(t *testing.T, cfg Config) {
+				plugins := cfg.Scheduler.Plugins
+				if len(plugins) != 1 || plugins[0].Name != "NodeResourcesFit" || len(plugins[0].DisabledAt) != 1 || plugins[0].Args.IsSet() {
+					t.Fatalf("unexpected plugins: %+v", plugins)
+				}
+				profPlugins := cfg.Scheduler.Profiles[0].Plugins
+				if len(profPlugins) != 1 || profPlugins[0].Name != "Coscheduling" || len(profPlugins[0].EnabledAt) != 2 || profPlugins[0].Weight != 2 {
+					t.Fatalf("unexpected profile plugins: %+v", profPlugins)
+				}
+				argsConf, err := profPlugins[0].Args.ToYAML()
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if argsConf != "permitWaitingTimeSeconds: 10\n" {
+					t.Errorf("unexpected args config: %q", argsConf)
+				}
+			},
+		},
+		{
+			name: "pod settings",
+			data: `apiVersion: deployer.topology.node.k8s.io/v1alpha1
+kind: DeployerConfiguration
+updater:
+  pod:
+    priorityClassName: infra-critical
+scheduler:
+  pod:
+    resources:
+      limits:
+        memory: 1Gi
+    tolerations:
+    - key: node-role.kubernetes.io/infra
+      operator: Exists
+  controllerPod:
+    topologySpreadConstraints:
+    - maxSkew: 1
+      topologyKey: topology.kubernetes.io/zone
+      whenUnsatisfiable: ScheduleAnyway
+`,
+			check: func(t *testing.T, cfg Config) {
+				if cfg.Updater.Pod == nil || cfg.Updater.Pod.PriorityClassName != "infra-critical" {
+					t.Errorf("unexpected updater pod config: %+v", cfg.Updater.Pod)
+				}
+				schedPod := cfg.Scheduler.Pod
+				if schedPod == nil || schedPod.Resources == nil || schedPod.Resources.Limits.Memory().String() != "1Gi" || len(schedPod.Tolerations) != 1 {
+					t.Errorf("unexpected scheduler pod config: %+v", schedPod)
+				}
+				ctrlPod := cfg.Scheduler.ControllerPod
+				if ctrlPod == nil || len(ctrlPod.TopologySpreadConstraints) != 1 || ctrlPod.TopologySpreadConstraints[0].TopologyKey != "topology.kubernetes.io/zone" {
+					t.Errorf("unexpected controller pod config: %+v", ctrlPod)
+				}
+			},
+		},
+		{
+			name: "JSON",
+			data: `{"apiVersion": "deployer.topology.node.k8s.io/v1alpha1", "kind": "DeployerConfiguration", "updater": {"verbose": 4}}`,
+			check: func(t *testing.T, cfg Config) {
+				if cfg.Updater == nil || cfg.Updater.Verbose == nil || *cfg.Updater.Verbose != 4 {
+					t.Errorf("unexpected updater fields: %+v", cfg.Updater)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := Decode([]byte(tc.data))
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("unexpected success")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.check != nil {
+				tc.check(t, cfg)
+			}
+		})
+	}
+}
+
+func TestDecodePodConfig(t *testing.T) {
+	podCfg, err := DecodePodConfig([]byte("priorityClassName: infra-critical\nnodeAffinity:\n  requiredDuringSchedulingIgnoredDuringExecution:\n    nodeSelectorTerms:\n    - matchExpressions:\n      - key: node-role.kubernetes.io/infra\n        operator: Exists\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if podCfg.PriorityClassName != "infra-critical" || podCfg.NodeAffinity == nil {
+		t.Errorf("unexpected pod config: %+v", podCfg)
+	}
+
+	if _, err := DecodePodConfig([]byte("priorityClass: infra-critical\n")); err == nil {
+		t.Errorf("unexpected success decoding unknown fields")
+	}
+}
@@ -17,6 +17,7 @@
 package deploy
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
@@ -25,6 +26,7 @@ import (
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform/detect"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/sched"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/updaters"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 )
 
@@ -32,7 +34,67 @@ func OnCluster(env *deployer.Environment, commonOpts *options.Options) error {
 	if err := env.EnsureClient(); err != nil {
 		return err
 	}
+	if err := DetectCluster(env, commonOpts); err != nil {
+		return err
+	}
+
+	components := []string{
+		manifests.ComponentAPI,
+		updaterComponent(commonOpts.UpdaterType),
+		manifests.ComponentSchedulerPlugin,
+	}
+
+	if commonOpts.Parallelism > 1 {
+		deployAll := func(env *deployer.Environment) error {
+			if err := deployGraph(env, commonOpts); err != nil {
+				return err
+			}
+			return RecordInventory(env, commonOpts, components...)
+		}
+		if env.DryRun {
+			return dryRunOutcome(env, deployAll(env))
+		}
+		return Transactionally(env, commonOpts.Transactional, deployAll)
+	}
+
+	if env.DryRun {
+		// nothing is persisted, so keep going to report all the rejected objects
+		err := errors.Join(
+			api.Deploy(env, apiOptionsFrom(commonOpts)),
+			updaters.Deploy(env, commonOpts.UpdaterType, updaterOptionsFrom(commonOpts)),
+			sched.Deploy(env, SchedulerOptionsFrom(commonOpts)),
+		)
+		if err != nil {
+			return dryRunOutcome(env, err)
+		}
+		return RecordInventory(env, commonOpts, components...)
+	}
+
+	return Transactionally(env, commonOpts.Transactional, func(env *deployer.Environment) error {
+		if err := api.Deploy(env, apiOptionsFrom(commonOpts)); err != nil {
+			return err
+		}
+		if err := updaters.Deploy(env, commonOpts.UpdaterType, updaterOptionsFrom(commonOpts)); err != nil {
+			return err
+		}
+		if err := sched.Deploy(env, SchedulerOptionsFrom(commonOpts)); err != nil {
+			return err
+		}
+		return RecordInventory(env, commonOpts, components...)
+	})
+}
+
+// dryRunOutcome summarizes the objects the dry-run could not validate. They still fail the dry-run.
+func dryRunOutcome(env *deployer.Environment, err error) error {
+	if errors.Is(err, deployer.ErrNotValidated) {
+		env.Log.Info("dry-run incomplete: some objects were not validated because their namespace does not exist yet")
+	}
+	return err
+}
 
+// DetectCluster fills the cluster platform and version in `commonOpts`,
+// using the user-provided values if any, or autodetecting them otherwise.
+func DetectCluster(env *deployer.Environment, commonOpts *options.Options) error {
 	platDetect, reason, _ := detect.FindPlatform(env.Ctx, commonOpts.UserPlatform)
 	commonOpts.ClusterPlatform = platDetect.Discovered
 	if commonOpts.ClusterPlatform == platform.Unknown {
@@ -45,25 +107,38 @@ func OnCluster(env *deployer.Environment, commonOpts *options.Options) error {
 	}
 
 	env.Log.V(3).Info("detection", "platform", commonOpts.ClusterPlatform, "reason", reason, "version", commonOpts.ClusterVersion, "source", source)
-	if err := api.Deploy(env, options.API{
-		Platform: commonOpts.ClusterPlatform,
-	}); err != nil {
-		return err
+	return nil
+}
+
+func apiOptionsFrom(commonOpts *options.Options) options.API {
+	return options.API{
+		Platform:  commonOpts.ClusterPlatform,
+		Apply:     commonOpts.Apply,
+		InstallID: commonOpts.InstallID,
 	}
-	if err := updaters.Deploy(env, commonOpts.UpdaterType, options.Updater{
+}
+
+func updaterOptionsFrom(commonOpts *options.Options) options.Updater {
+	return options.Updater{
 		Platform:            commonOpts.ClusterPlatform,
 		PlatformVersion:     commonOpts.ClusterVersion,
 		WaitCompletion:      commonOpts.WaitCompletion,
+		Apply:               commonOpts.Apply,
 		RTEConfigData:       commonOpts.RTEConfigData,
 		DaemonSet:           options.ForDaemonSet(commonOpts),
 		EnableCRIHooks:      commonOpts.UpdaterCRIHooksEnable,
 		CustomSELinuxPolicy: commonOpts.UpdaterCustomSELinuxPolicy,
-	}); err != nil {
-		return err
+		InstallID:           commonOpts.InstallID,
 	}
-	if err := sched.Deploy(env, options.Scheduler{
+}
+
+// SchedulerOptionsFrom returns the scheduler options set by `commonOpts`, with the cluster platform and version.
+// The callers override the fields their command sets differently.
+func SchedulerOptionsFrom(commonOpts *options.Options) options.Scheduler {
+	return options.Scheduler{
 		Platform:               commonOpts.ClusterPlatform,
 		WaitCompletion:         commonOpts.WaitCompletion,
+		Apply:                  commonOpts.Apply,
 		Replicas:               int32(commonOpts.Replicas),
 		PullIfNotPresent:       commonOpts.PullIfNotPresent,
 		ProfileName:            commonOpts.SchedProfileName,
@@ -72,10 +147,16 @@ func OnCluster(env *deployer.Environment, commonOpts *options.Options) error {
 		Verbose:                commonOpts.SchedVerbose,
 		ScoringStratConfigData: commonOpts.SchedScoringStratConfigData,
 		CacheParamsConfigData:  commonOpts.SchedCacheParamsConfigData,
+		Profiles:               commonOpts.SchedProfiles,
+		Plugins:                commonOpts.SchedPlugins,
+		SchedulerPod:           commonOpts.SchedPodSettings,
+		ControllerPod:          commonOpts.SchedCtrlPodSettings,
+		PreemptionMode:         commonOpts.SchedPreemptionMode,
+		InformerMode:           commonOpts.SchedInformerMode,
+		ConfigAPIVersion:       commonOpts.SchedConfigAPIVersion,
+		PlatformVersion:        commonOpts.ClusterVersion,
 		LeaderElection:         commonOpts.Replicas > 1,
 		LeaderElectionResource: commonOpts.SchedLeaderElectResource,
-	}); err != nil {
-		return err
+		InstallID:              commonOpts.InstallID,
 	}
-	return nil
 }
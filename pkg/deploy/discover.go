@@ -0,0 +1,201 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deploy
+
+import (
+	"errors"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/wait"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/inventory"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+)
+
+// the deployer may have labeled these namespaces applying its manifests,
+// but never created them, so it must never delete them.
+var protectedNamespaces = map[string]bool{
+	"default":         true,
+	"kube-system":     true,
+	"kube-public":     true,
+	"kube-node-lease": true,
+}
+
+// Discover returns the objects installed by the deployer for the installation selected by
+// `commonOpts`: the objects recorded in the inventory, plus the objects of all the kinds the
+// deployer knows which carry the ownership labels of the installation. Unlike the manifests,
+// the result does not depend on the options. The objects are sorted in removal order.
+func Discover(env *deployer.Environment, commonOpts *options.Options) ([]inventory.Object, error) {
+	if err := env.EnsureClient(); err != nil {
+		return nil, err
+	}
+
+	var objs []inventory.Object
+	inv, err := LoadInventory(env, commonOpts)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	if inv != nil {
+		for _, comp := range inv.Components {
+			objs = append(objs, comp.Objects...)
+		}
+	}
+
+	selector := client.MatchingLabels(manifests.InstallSelector(commonOpts.InstallID))
+	for _, gvk := range manifests.KnownKinds() {
+		liveList := &unstructured.UnstructuredList{}
+		liveList.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		err := env.Cli.List(env.Ctx, liveList, selector)
+		if meta.IsNoMatchError(err) {
+			env.Log.V(4).Info("kind not served, skipped", "kind", gvk.Kind)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range liveList.Items {
+			if item.GetLabels()[manifests.LabelAppComponent] == inventory.ComponentInventory {
+				continue
+			}
+			apiVersion, kind := gvk.ToAPIVersionAndKind()
+			objs = append(objs, inventory.Object{
+				APIVersion: apiVersion,
+				Kind:       kind,
+				Namespace:  item.GetNamespace(),
+				Name:       item.GetName(),
+			})
+		}
+	}
+	return sortForRemoval(dedupObjects(objs)), nil
+}
+
+// Orphans returns the objects among `objs` which the deployer would not create with the current options.
+func Orphans(env *deployer.Environment, commonOpts *options.Options, objs []inventory.Object) ([]inventory.Object, error) {
+	rendered := make(map[string]bool)
+	components := []string{
+		manifests.ComponentAPI,
+		updaterComponent(commonOpts.UpdaterType),
+		manifests.ComponentSchedulerPlugin,
+	}
+	for _, component := range components {
+		compObjs, err := ComponentObjects(env, commonOpts, component)
+		if err != nil {
+			return nil, err
+		}
+		refs, err := inventory.ObjectsFrom(env.Cli.Scheme(), compObjs)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range refs {
+			rendered[ref.String()] = true
+		}
+	}
+
+	var orphans []inventory.Object
+	for _, obj := range objs {
+		if !rendered[obj.String()] {
+			orphans = append(orphans, obj)
+		}
+	}
+	return orphans, nil
+}
+
+// RemoveObjects deletes the given objects in the given order. The objects already gone are
+// skipped. It keeps going on failure to remove as much as possible, and returns all the errors.
+func RemoveObjects(env *deployer.Environment, commonOpts *options.Options, objs []inventory.Object) error {
+	var errs []error
+	var deleted []*unstructured.Unstructured
+	for _, ref := range objs {
+		if ref.Kind == "Namespace" && protectedNamespaces[ref.Name] {
+			env.Log.Info("skipping protected namespace", "name", ref.Name)
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind))
+		obj.SetNamespace(ref.Namespace)
+		obj.SetName(ref.Name)
+
+		err := env.DeleteObject(obj)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			if env.DryRun {
+				err = env.DryRunRemoveError(obj, err)
+			}
+			if err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		deleted = append(deleted, obj)
+	}
+
+	if env.DryRun || !commonOpts.WaitCompletion {
+		return errors.Join(errs...)
+	}
+	for _, obj := range deleted {
+		err := wait.With(env.Cli, env.Log).ForObjectDeleted(env.Ctx, obj)
+		if err != nil {
+			env.Log.Info("failed to wait for removal", "kind", obj.GetKind(), "name", obj.GetName(), "error", err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func dedupObjects(objs []inventory.Object) []inventory.Object {
+	seen := make(map[string]bool)
+	var ret []inventory.Object
+	for _, obj := range objs {
+		key := obj.String()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		ret = append(ret, obj)
+	}
+	return ret
+}
+
+func sortForRemoval(objs []inventory.Object) []inventory.Object {
+	order := make(map[string]int)
+	for idx, gvk := range manifests.KnownKinds() {
+		order[gvk.Kind] = idx
+	}
+	rank := func(kind string) int {
+		if idx, ok := order[kind]; ok {
+			return idx
+		}
+		return -1 // unknown kinds first, like the workloads
+	}
+	sort.SliceStable(objs, func(i, j int) bool {
+		ri, rj := rank(objs[i].Kind), rank(objs[j].Kind)
+		if ri != rj {
+			return ri < rj
+		}
+		return objs[i].String() < objs[j].String()
+	})
+	return objs
+}
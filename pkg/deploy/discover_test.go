@@ -0,0 +1,171 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deploy
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/inventory"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+)
+
+func newFakeEnv(t *testing.T, objs ...client.Object) *deployer.Environment {
+	return &deployer.Environment{
+		Ctx: context.Background(),
+		Cli: fake.NewClientBuilder().WithObjects(objs...).Build(),
+		Log: testr.New(t),
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	commonOpts := newTestOptions(platform.Kubernetes, "1.30")
+	commonOpts.InstallID = "test"
+
+	// recorded in the inventory, but without labels: found only by inventory
+	recorded := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "recorded", Namespace: "foo"}}
+	// labeled, but not recorded in the inventory: found only by labels
+	labeled := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "labeled", Namespace: "foo"}}
+	manifests.StampObject(labeled, manifests.ComponentSchedulerPlugin, commonOpts.InstallID)
+	// owned by another installation
+	other := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "foo"}}
+	manifests.StampObject(other, manifests.ComponentSchedulerPlugin, "other")
+	// not owned by the deployer at all
+	foreign := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "foreign", Namespace: "foo"}}
+
+	inv := inventory.New(commonOpts.InstallID)
+	inv.SetComponent(manifests.ComponentSchedulerPlugin, []inventory.Object{
+		{APIVersion: "v1", Kind: "ServiceAccount", Namespace: "foo", Name: "recorded"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "foo", Name: "labeled"},
+	})
+	invCM, err := inv.ToConfigMap(inventoryNamespace(commonOpts))
+	if err != nil {
+		t.Fatalf("cannot create the inventory: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		objs     []client.Object
+		expected []string
+	}{
+		{
+			name:     "inventory and labels",
+			objs:     []client.Object{invCM, recorded, labeled, other, foreign},
+			expected: []string{"ConfigMap/foo/labeled", "ServiceAccount/foo/recorded"},
+		},
+		{
+			name:     "labels only",
+			objs:     []client.Object{recorded, labeled, other, foreign},
+			expected: []string{"ConfigMap/foo/labeled"},
+		},
+		{
+			name: "nothing installed",
+			objs: []client.Object{other, foreign},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := newFakeEnv(t, tc.objs...)
+			objs, err := Discover(env, commonOpts)
+			if err != nil {
+				t.Fatalf("discover failed: %v", err)
+			}
+			if got := objectNames(objs); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("got %v expected %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestOrphans(t *testing.T) {
+	commonOpts := newTestOptions(platform.Kubernetes, "1.30")
+	env := newFakeEnv(t)
+
+	rendered, err := ComponentObjects(env, commonOpts, manifests.ComponentSchedulerPlugin)
+	if err != nil {
+		t.Fatalf("cannot render: %v", err)
+	}
+	objs, err := inventory.ObjectsFrom(env.Cli.Scheme(), rendered)
+	if err != nil {
+		t.Fatalf("cannot convert: %v", err)
+	}
+	stale := inventory.Object{APIVersion: "v1", Kind: "ConfigMap", Namespace: "foo", Name: "stale"}
+	objs = append(objs, stale)
+
+	orphans, err := Orphans(env, commonOpts, objs)
+	if err != nil {
+		t.Fatalf("orphans failed: %v", err)
+	}
+	if expected := []inventory.Object{stale}; !reflect.DeepEqual(orphans, expected) {
+		t.Errorf("got %v expected %v", orphans, expected)
+	}
+}
+
+func TestRemoveObjects(t *testing.T) {
+	commonOpts := newTestOptions(platform.Kubernetes, "1.30")
+	objs := []client.Object{
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "foo"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foo"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+	}
+	env := newFakeEnv(t, objs...)
+
+	refs := []inventory.Object{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "foo", Name: "cm"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "foo", Name: "gone"},
+		{APIVersion: "v1", Kind: "Namespace", Name: "foo"},
+		{APIVersion: "v1", Kind: "Namespace", Name: "kube-system"},
+		{APIVersion: "v1", Kind: "Namespace", Name: "default"},
+	}
+	if err := RemoveObjects(env, commonOpts, refs); err != nil {
+		t.Fatalf("remove failed: %v", err)
+	}
+
+	for _, obj := range objs {
+		err := env.Cli.Get(env.Ctx, client.ObjectKeyFromObject(obj), obj)
+		_, isNS := obj.(*corev1.Namespace)
+		expectRemoved := !isNS || !protectedNamespaces[obj.GetName()]
+		if expectRemoved && !apierrors.IsNotFound(err) {
+			t.Errorf("%q not removed: %v", obj.GetName(), err)
+		}
+		if !expectRemoved && err != nil {
+			t.Errorf("protected namespace %q removed: %v", obj.GetName(), err)
+		}
+	}
+}
+
+func objectNames(objs []inventory.Object) []string {
+	var ret []string
+	for _, obj := range objs {
+		ret = append(ret, obj.String())
+	}
+	return ret
+}
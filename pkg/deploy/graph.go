@@ -0,0 +1,130 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deploy
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/api"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/sched"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/updaters"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/objectwait"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+)
+
+type graphComponent struct {
+	name    string
+	logName string
+	objs    []objectwait.WaitableObject
+	wait    bool
+}
+
+// deployGraph deploys all the components at once, creating and waiting for
+// the independent objects concurrently. See buildGraph for the dependencies.
+func deployGraph(env *deployer.Environment, commonOpts *options.Options) error {
+	apiObjs, err := api.Creatable(env.WithName("API"), apiOptionsFrom(commonOpts))
+	if err != nil {
+		return err
+	}
+	updaterObjs, err := updaters.Creatable(env.WithName(commonOpts.UpdaterType), commonOpts.UpdaterType, updaterOptionsFrom(commonOpts))
+	if err != nil {
+		return err
+	}
+	schedObjs, err := sched.Creatable(env.WithName("SCD"), SchedulerOptionsFrom(commonOpts))
+	if err != nil {
+		return err
+	}
+
+	nodes := buildGraph([]graphComponent{
+		// like api.Deploy, always wait for the API to be ready
+		{name: manifests.ComponentAPI, logName: "API", objs: apiObjs, wait: true},
+		{name: updaterComponent(commonOpts.UpdaterType), logName: commonOpts.UpdaterType, objs: updaterObjs, wait: commonOpts.WaitCompletion},
+		{name: manifests.ComponentSchedulerPlugin, logName: "SCD", objs: schedObjs, wait: commonOpts.WaitCompletion},
+	})
+	env.Log.Info("deploying topology-aware-scheduling", "objects", len(nodes), "parallelism", commonOpts.Parallelism)
+	if err := env.CreateOrApplyGraph(nodes, commonOpts.Apply, commonOpts.Parallelism); err != nil {
+		return err
+	}
+	env.Log.Info("deployed topology-aware-scheduling")
+	return nil
+}
+
+// buildGraph computes the dependencies among the objects of the components:
+// the namespaced objects depend on their namespace, if created as well;
+// the workloads depend on all the other objects of their component (RBAC, configuration);
+// the workloads of the other components, which consume the NodeResourceTopology
+// objects, depend on the API.
+func buildGraph(comps []graphComponent) []deployer.GraphNode {
+	var nodes []deployer.GraphNode
+	var apiIdxs []int
+	nsIdxs := make(map[string]int)
+	compIdxs := make([][]int, len(comps))
+	for compIdx, comp := range comps {
+		for _, wo := range comp.objs {
+			idx := len(nodes)
+			node := deployer.GraphNode{
+				Name: comp.logName,
+				Obj:  wo.Obj,
+			}
+			if comp.wait {
+				node.Wait = wo.Wait
+			}
+			nodes = append(nodes, node)
+			compIdxs[compIdx] = append(compIdxs[compIdx], idx)
+			if comp.name == manifests.ComponentAPI {
+				apiIdxs = append(apiIdxs, idx)
+			}
+			if _, ok := wo.Obj.(*corev1.Namespace); ok {
+				nsIdxs[wo.Obj.GetName()] = idx
+			}
+		}
+	}
+
+	for compIdx, comp := range comps {
+		for _, idx := range compIdxs[compIdx] {
+			obj := nodes[idx].Obj
+			if nsIdx, ok := nsIdxs[obj.GetNamespace()]; ok {
+				nodes[idx].Deps = append(nodes[idx].Deps, nsIdx)
+			}
+			if !isWorkload(obj) {
+				continue
+			}
+			for _, otherIdx := range compIdxs[compIdx] {
+				if otherIdx != idx && !isWorkload(nodes[otherIdx].Obj) {
+					nodes[idx].Deps = append(nodes[idx].Deps, otherIdx)
+				}
+			}
+			if comp.name != manifests.ComponentAPI {
+				nodes[idx].Deps = append(nodes[idx].Deps, apiIdxs...)
+			}
+		}
+	}
+	return nodes
+}
+
+func isWorkload(obj client.Object) bool {
+	switch obj.(type) {
+	case *appsv1.Deployment, *appsv1.DaemonSet:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deploy
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/inventory"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+)
+
+// LoadInventory reads the inventory of the installation selected by `commonOpts`.
+// Returns a NotFound error if the inventory does not exist.
+func LoadInventory(env *deployer.Environment, commonOpts *options.Options) (*inventory.Inventory, error) {
+	if err := env.EnsureClient(); err != nil {
+		return nil, err
+	}
+	return inventory.Load(env.Ctx, env.Cli, inventoryNamespace(commonOpts), commonOpts.InstallID)
+}
+
+// RecordInventory records in the inventory the objects of the given components,
+// as rendered from `commonOpts`, replacing the previous records of the same components.
+// The inventory namespace is created if missing.
+func RecordInventory(env *deployer.Environment, commonOpts *options.Options, components ...string) error {
+	inv, err := LoadInventory(env, commonOpts)
+	if apierrors.IsNotFound(err) {
+		inv = inventory.New(commonOpts.InstallID)
+	} else if err != nil {
+		return err
+	}
+
+	inv.DeployerVersion = manifests.DeployerVersion
+	for _, component := range components {
+		objs, err := ComponentObjects(env, commonOpts, component)
+		if err != nil {
+			return err
+		}
+		refs, err := inventory.ObjectsFrom(env.Cli.Scheme(), objs)
+		if err != nil {
+			return err
+		}
+		inv.SetComponent(component, refs)
+	}
+
+	cm, err := inv.ToConfigMap(inventoryNamespace(commonOpts))
+	if err != nil {
+		return err
+	}
+	if err := ensureInventoryNamespace(env, cm.Namespace, inv.InstallID); err != nil {
+		return err
+	}
+	env.Log.V(3).Info("recording inventory", "name", cm.Name, "namespace", cm.Namespace, "components", components)
+	err = env.ApplyObject(cm)
+	if err != nil && env.DryRun {
+		return env.DryRunError(cm, err)
+	}
+	return err
+}
+
+// ForgetInventory drops the given components from the inventory,
+// deleting the inventory once no component is left.
+func ForgetInventory(env *deployer.Environment, commonOpts *options.Options, components ...string) error {
+	inv, err := LoadInventory(env, commonOpts)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, component := range components {
+		inv.RemoveComponent(component)
+	}
+	return saveOrDeleteInventory(env, commonOpts, inv)
+}
+
+// ForgetObjects drops the given objects from the inventory,
+// deleting the inventory once no object is left.
+func ForgetObjects(env *deployer.Environment, commonOpts *options.Options, objs []inventory.Object) error {
+	inv, err := LoadInventory(env, commonOpts)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	inv.RemoveObjects(objs)
+	return saveOrDeleteInventory(env, commonOpts, inv)
+}
+
+func saveOrDeleteInventory(env *deployer.Environment, commonOpts *options.Options, inv *inventory.Inventory) error {
+	cm, err := inv.ToConfigMap(inventoryNamespace(commonOpts))
+	if err != nil {
+		return err
+	}
+	if !inv.IsEmpty() {
+		return env.ApplyObject(cm)
+	}
+	err = env.DeleteObject(cm)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return cleanupInventoryNamespace(env, cm.Namespace)
+}
+
+// ensureInventoryNamespace creates the inventory namespace if missing, labeled as part of the inventory
+// so the removal of the components leaves it alone. Existing namespaces are used as they are.
+func ensureInventoryNamespace(env *deployer.Environment, namespace, installID string) error {
+	ns := &corev1.Namespace{}
+	err := env.Cli.Get(env.Ctx, client.ObjectKey{Name: namespace}, ns)
+	if err == nil {
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+	ns = &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: namespace,
+		},
+	}
+	manifests.StampObject(ns, inventory.ComponentInventory, installID)
+	return env.CreateObject(ns)
+}
+
+// cleanupInventoryNamespace deletes the inventory namespace once it holds no inventory,
+// if the deployer created it.
+func cleanupInventoryNamespace(env *deployer.Environment, namespace string) error {
+	ns := &corev1.Namespace{}
+	err := env.Cli.Get(env.Ctx, client.ObjectKey{Name: namespace}, ns)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if ns.Labels[manifests.LabelAppManagedBy] != manifests.ManagedBy || ns.Labels[manifests.LabelAppComponent] != inventory.ComponentInventory {
+		return nil
+	}
+	cms := &corev1.ConfigMapList{}
+	err = env.Cli.List(env.Ctx, cms, client.InNamespace(namespace), client.MatchingLabels{
+		manifests.LabelAppManagedBy: manifests.ManagedBy,
+		manifests.LabelAppComponent: inventory.ComponentInventory,
+	})
+	if err != nil {
+		return err
+	}
+	if len(cms.Items) > 0 {
+		return nil
+	}
+	ns.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Namespace"))
+	err = env.DeleteObject(ns)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func inventoryNamespace(commonOpts *options.Options) string {
+	if commonOpts.InventoryNamespace == "" {
+		return inventory.DefaultNamespace
+	}
+	return commonOpts.InventoryNamespace
+}
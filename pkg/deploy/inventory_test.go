@@ -0,0 +1,87 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deploy
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/inventory"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+)
+
+func TestRecordAndForgetInventory(t *testing.T) {
+	commonOpts := newTestOptions(platform.Kubernetes, "1.30")
+	env := newFakeEnv(t)
+
+	if err := RecordInventory(env, commonOpts, manifests.ComponentAPI, "rte"); err != nil {
+		t.Fatalf("cannot record the inventory: %v", err)
+	}
+
+	ns := &corev1.Namespace{}
+	if err := env.Cli.Get(env.Ctx, client.ObjectKey{Name: inventory.DefaultNamespace}, ns); err != nil {
+		t.Fatalf("inventory namespace not created: %v", err)
+	}
+	if ns.Labels[manifests.LabelAppComponent] != inventory.ComponentInventory {
+		t.Errorf("unexpected inventory namespace labels %v", ns.Labels)
+	}
+
+	inv, err := LoadInventory(env, commonOpts)
+	if err != nil {
+		t.Fatalf("cannot load the inventory: %v", err)
+	}
+	if inv.Version != inventory.Version || len(inv.Components) != 2 {
+		t.Errorf("unexpected inventory %+v", inv)
+	}
+
+	if err := ForgetInventory(env, commonOpts, manifests.ComponentAPI, "rte"); err != nil {
+		t.Fatalf("cannot forget the inventory: %v", err)
+	}
+	if _, err := LoadInventory(env, commonOpts); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the inventory deleted, got %v", err)
+	}
+	err = env.Cli.Get(env.Ctx, client.ObjectKey{Name: inventory.DefaultNamespace}, &corev1.Namespace{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the inventory namespace deleted, got %v", err)
+	}
+}
+
+func TestForgetInventoryKeepsUserNamespace(t *testing.T) {
+	commonOpts := newTestOptions(platform.Kubernetes, "1.30")
+	commonOpts.InventoryNamespace = "ops"
+	env := newFakeEnv(t, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ops"}})
+
+	if err := RecordInventory(env, commonOpts, manifests.ComponentAPI); err != nil {
+		t.Fatalf("cannot record the inventory: %v", err)
+	}
+	if err := ForgetInventory(env, commonOpts, manifests.ComponentAPI); err != nil {
+		t.Fatalf("cannot forget the inventory: %v", err)
+	}
+	ns := &corev1.Namespace{}
+	if err := env.Cli.Get(env.Ctx, client.ObjectKey{Name: "ops"}, ns); err != nil {
+		t.Fatalf("expected the user namespace kept, got %v", err)
+	}
+	if len(ns.Labels) != 0 {
+		t.Errorf("expected the user namespace untouched, got labels %v", ns.Labels)
+	}
+}
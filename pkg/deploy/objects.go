@@ -0,0 +1,95 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deploy
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/updaters"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	apimanifests "github.com/k8stopologyawareschedwg/deployer/pkg/manifests/api"
+	schedmanifests "github.com/k8stopologyawareschedwg/deployer/pkg/manifests/sched"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+)
+
+// APIObjects returns the objects OnCluster creates for the API.
+func APIObjects(commonOpts *options.Options) ([]client.Object, error) {
+	mf, err := apimanifests.NewWithOptions(options.Render{
+		Platform: commonOpts.ClusterPlatform,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return manifests.StampObjects(mf.ToObjects(), manifests.ComponentAPI, commonOpts.InstallID), nil
+}
+
+// UpdaterObjects returns the objects OnCluster creates for the given updater type,
+// including its namespace.
+func UpdaterObjects(commonOpts *options.Options, updaterType string) ([]client.Object, error) {
+	ns, namespace, err := updaters.SetupNamespace(updaterType)
+	if err != nil {
+		return nil, err
+	}
+	objs, err := updaters.GetObjects(updaterOptionsFrom(commonOpts), updaterType, namespace)
+	if err != nil {
+		return nil, err
+	}
+	objs = append([]client.Object{ns}, objs...)
+	return manifests.StampObjects(objs, updaterComponent(updaterType), commonOpts.InstallID), nil
+}
+
+func schedulerManifests(env *deployer.Environment, commonOpts *options.Options) (schedmanifests.Manifests, error) {
+	mf, err := schedmanifests.NewWithOptions(options.Render{
+		Platform: commonOpts.ClusterPlatform,
+	})
+	if err != nil {
+		return mf, err
+	}
+	return mf.Render(env.Log, SchedulerOptionsFrom(commonOpts))
+}
+
+// SchedulerObjects returns the objects OnCluster creates for the scheduler plugin.
+func SchedulerObjects(env *deployer.Environment, commonOpts *options.Options) ([]client.Object, error) {
+	mf, err := schedulerManifests(env, commonOpts)
+	if err != nil {
+		return nil, err
+	}
+	return manifests.StampObjects(mf.ToObjects(), manifests.ComponentSchedulerPlugin, commonOpts.InstallID), nil
+}
+
+// ComponentObjects returns the objects OnCluster creates for the given component.
+func ComponentObjects(env *deployer.Environment, commonOpts *options.Options, component string) ([]client.Object, error) {
+	switch component {
+	case manifests.ComponentAPI:
+		return APIObjects(commonOpts)
+	case manifests.ComponentResourceTopologyExporter:
+		return UpdaterObjects(commonOpts, updaters.RTE)
+	case manifests.ComponentNodeFeatureDiscovery:
+		return UpdaterObjects(commonOpts, updaters.NFD)
+	case manifests.ComponentSchedulerPlugin:
+		return SchedulerObjects(env, commonOpts)
+	}
+	return nil, fmt.Errorf("unknown component %q", component)
+}
+
+func updaterComponent(updaterType string) string {
+	return strings.ToLower(updaterType)
+}
@@ -0,0 +1,51 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deploy
+
+import (
+	"fmt"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+)
+
+// Transactionally runs `fn`. If `enabled`, the changes `fn` makes through the
+// environment are recorded, and rolled back in reverse order if `fn` fails.
+func Transactionally(env *deployer.Environment, enabled bool, fn func(env *deployer.Environment) error) error {
+	if !enabled {
+		return fn(env)
+	}
+
+	txEnv := *env
+	txEnv.Journal = deployer.NewJournal()
+	err := fn(&txEnv)
+	if err == nil {
+		return nil
+	}
+
+	env.Log.Info("rolling back", "changes", txEnv.Journal.Len(), "error", err)
+	failed := 0
+	results := txEnv.Rollback()
+	for _, res := range results {
+		if res.Error != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%w (rollback: %d changes undone, %d failed)", err, len(results)-failed, failed)
+	}
+	return fmt.Errorf("%w (rollback: %d changes undone)", err, len(results))
+}
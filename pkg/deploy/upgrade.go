@@ -0,0 +1,415 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deploy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/api"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/sched"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/updaters"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/inventory"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	apimanifests "github.com/k8stopologyawareschedwg/deployer/pkg/manifests/api"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+)
+
+type UpgradeAction string
+
+const (
+	UpgradeActionCreate UpgradeAction = "create"
+	UpgradeActionUpdate UpgradeAction = "update"
+	// UpgradeActionDelete removes the installed objects the deployer no longer renders
+	UpgradeActionDelete UpgradeAction = "delete"
+)
+
+// UpgradeChange describes the change the upgrade will make on a single object
+type UpgradeChange struct {
+	Component  string
+	Action     UpgradeAction
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+	Details    []string
+}
+
+func (uc UpgradeChange) String() string {
+	name := uc.Name
+	if uc.Namespace != "" {
+		name = uc.Namespace + "/" + name
+	}
+	ret := fmt.Sprintf("%-6s %-6s %s %s", uc.Action, uc.Component, uc.Kind, name)
+	if len(uc.Details) > 0 {
+		ret += " (" + strings.Join(uc.Details, "; ") + ")"
+	}
+	return ret
+}
+
+// UpgradePlan describes the installed stack and the changes needed to bring it
+// to the manifests this deployer ships. Components which are not installed are
+// not part of the plan.
+type UpgradePlan struct {
+	Platform    platform.Platform
+	Version     platform.Version
+	API         bool
+	UpdaterType string
+	Scheduler   bool
+	Changes     []UpgradeChange
+}
+
+func (plan UpgradePlan) IsEmpty() bool {
+	return len(plan.Changes) == 0
+}
+
+// PlanUpgrade detects the installed components and computes the changes needed
+// to upgrade them. It does not change the cluster state.
+func PlanUpgrade(env *deployer.Environment, commonOpts *options.Options) (UpgradePlan, error) {
+	if err := env.EnsureClient(); err != nil {
+		return UpgradePlan{}, err
+	}
+	if err := DetectCluster(env, commonOpts); err != nil {
+		return UpgradePlan{}, err
+	}
+	return planUpgrade(env, commonOpts)
+}
+
+func planUpgrade(env *deployer.Environment, commonOpts *options.Options) (UpgradePlan, error) {
+	plan := UpgradePlan{}
+	plan.Platform = commonOpts.ClusterPlatform
+	plan.Version = commonOpts.ClusterVersion
+
+	apiMf, err := apimanifests.NewWithOptions(options.Render{
+		Platform: commonOpts.ClusterPlatform,
+	})
+	if err != nil {
+		return plan, err
+	}
+	plan.API, err = isInstalled(env, apiMf.Crd)
+	if err != nil {
+		return plan, err
+	}
+
+	var updaterObjs []client.Object
+	for _, updaterType := range []string{updaters.RTE, updaters.NFD} {
+		objs, err := UpdaterObjects(commonOpts, updaterType)
+		if err != nil {
+			return plan, err
+		}
+		ds := findDaemonSet(objs)
+		if ds == nil {
+			continue
+		}
+		found, err := isInstalled(env, ds)
+		if err != nil {
+			return plan, err
+		}
+		if found {
+			plan.UpdaterType = updaterType
+			updaterObjs = objs
+			break
+		}
+	}
+	if plan.UpdaterType != "" && plan.UpdaterType != commonOpts.UpdaterType {
+		env.Log.Info("upgrading the installed updater", "installed", plan.UpdaterType, "requested", commonOpts.UpdaterType)
+	}
+
+	schedMf, err := schedulerManifests(env, commonOpts)
+	if err != nil {
+		return plan, err
+	}
+	plan.Scheduler, err = isInstalled(env, schedMf.DPScheduler)
+	if err != nil {
+		return plan, err
+	}
+
+	if !plan.API && plan.UpdaterType == "" && !plan.Scheduler {
+		return plan, fmt.Errorf("no topology-aware-scheduling installation found")
+	}
+
+	components := []struct {
+		name    string
+		enabled bool
+		objs    []client.Object
+	}{
+		{name: manifests.ComponentAPI, enabled: plan.API, objs: manifests.StampObjects(apiMf.ToObjects(), manifests.ComponentAPI, commonOpts.InstallID)},
+		{name: updaterComponent(plan.UpdaterType), enabled: plan.UpdaterType != "", objs: updaterObjs},
+		{name: manifests.ComponentSchedulerPlugin, enabled: plan.Scheduler, objs: manifests.StampObjects(schedMf.ToObjects(), manifests.ComponentSchedulerPlugin, commonOpts.InstallID)},
+	}
+	for _, comp := range components {
+		if !comp.enabled {
+			continue
+		}
+		for _, obj := range comp.objs {
+			change, err := computeChange(env, comp.name, obj)
+			if err != nil {
+				return plan, err
+			}
+			if change == nil {
+				continue
+			}
+			plan.Changes = append(plan.Changes, *change)
+		}
+	}
+
+	deletions, err := planDeletions(env, commonOpts, plan.UpdaterType)
+	if err != nil {
+		return plan, err
+	}
+	plan.Changes = append(plan.Changes, deletions...)
+	return plan, nil
+}
+
+// planDeletions finds the installed objects which the deployer no longer renders, like
+// the objects dropped by a newer version of the manifests. They are listed in removal order.
+func planDeletions(env *deployer.Environment, commonOpts *options.Options, updaterType string) ([]UpgradeChange, error) {
+	installed, err := Discover(env, commonOpts)
+	if err != nil {
+		return nil, err
+	}
+	renderOpts := *commonOpts
+	if updaterType != "" {
+		renderOpts.UpdaterType = updaterType
+	}
+	orphans, err := Orphans(env, &renderOpts, installed)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []UpgradeChange
+	for _, ref := range orphans {
+		if ref.Kind == "Namespace" && protectedNamespaces[ref.Name] {
+			continue
+		}
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind))
+		err := env.Cli.Get(env.Ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, live)
+		if apierrors.IsNotFound(err) {
+			continue // stale inventory record
+		}
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, UpgradeChange{
+			Component:  live.GetLabels()[manifests.LabelAppComponent],
+			Action:     UpgradeActionDelete,
+			APIVersion: ref.APIVersion,
+			Kind:       ref.Kind,
+			Namespace:  ref.Namespace,
+			Name:       ref.Name,
+		})
+	}
+	return changes, nil
+}
+
+func (plan UpgradePlan) deletions() []inventory.Object {
+	var objs []inventory.Object
+	for _, change := range plan.Changes {
+		if change.Action != UpgradeActionDelete {
+			continue
+		}
+		objs = append(objs, inventory.Object{
+			APIVersion: change.APIVersion,
+			Kind:       change.Kind,
+			Namespace:  change.Namespace,
+			Name:       change.Name,
+		})
+	}
+	return objs
+}
+
+// Upgrade applies the manifests of the components found installed by PlanUpgrade,
+// waiting for each component to be rolled out before moving to the next one.
+// The order is API, updater, scheduler: the scheduler plugin consumes the data
+// published by the updater, which needs the API. The objects no longer rendered
+// are deleted last, once their replacements are rolled out.
+func Upgrade(env *deployer.Environment, commonOpts *options.Options, plan UpgradePlan) error {
+	if plan.IsEmpty() {
+		env.Log.Info("installation up to date, nothing to upgrade")
+		return nil
+	}
+
+	env.Log.Info("upgrading topology-aware-scheduling", "changes", len(plan.Changes))
+	var components []string
+	if plan.API {
+		apiOpts := apiOptionsFrom(commonOpts)
+		apiOpts.Apply = true
+		if err := api.Deploy(env, apiOpts); err != nil {
+			return err
+		}
+		components = append(components, manifests.ComponentAPI)
+	}
+	if plan.UpdaterType != "" {
+		updaterOpts := updaterOptionsFrom(commonOpts)
+		updaterOpts.Apply = true
+		updaterOpts.WaitCompletion = true
+		if err := updaters.Deploy(env, plan.UpdaterType, updaterOpts); err != nil {
+			return err
+		}
+		components = append(components, updaterComponent(plan.UpdaterType))
+	}
+	if plan.Scheduler {
+		schedOpts := SchedulerOptionsFrom(commonOpts)
+		schedOpts.Apply = true
+		schedOpts.WaitCompletion = true
+		if err := sched.Deploy(env, schedOpts); err != nil {
+			return err
+		}
+		components = append(components, manifests.ComponentSchedulerPlugin)
+	}
+	if obsolete := plan.deletions(); len(obsolete) > 0 {
+		env.Log.Info("removing obsolete objects", "count", len(obsolete))
+		if err := RemoveObjects(env, commonOpts, obsolete); err != nil {
+			return err
+		}
+		if err := ForgetObjects(env, commonOpts, obsolete); err != nil {
+			return err
+		}
+	}
+	if err := RecordInventory(env, commonOpts, components...); err != nil {
+		return err
+	}
+	env.Log.Info("upgraded topology-aware-scheduling")
+	return nil
+}
+
+func findDaemonSet(objs []client.Object) *appsv1.DaemonSet {
+	for _, obj := range objs {
+		if ds, ok := obj.(*appsv1.DaemonSet); ok {
+			return ds
+		}
+	}
+	return nil
+}
+
+func isInstalled(env *deployer.Environment, obj client.Object) (bool, error) {
+	_, err := getLiveObject(env, obj)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func getLiveObject(env *deployer.Environment, obj client.Object) (*unstructured.Unstructured, error) {
+	gvk, err := apiutil.GVKForObject(obj, env.Cli.Scheme())
+	if err != nil {
+		return nil, err
+	}
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(gvk)
+	err = env.Cli.Get(env.Ctx, client.ObjectKeyFromObject(obj), live)
+	return live, err
+}
+
+func computeChange(env *deployer.Environment, component string, obj client.Object) (*UpgradeChange, error) {
+	desired, err := manifests.NormalizeObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	live, err := getLiveObject(env, obj)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	change := UpgradeChange{
+		Component:  component,
+		APIVersion: live.GetAPIVersion(),
+		Kind:       live.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+	if apierrors.IsNotFound(err) {
+		change.Action = UpgradeActionCreate
+		return &change, nil
+	}
+	if manifests.ObjectMatches(desired.Object, live.Object) {
+		return nil, nil
+	}
+	change.Action = UpgradeActionUpdate
+	change.Details = describeChanges(desired.Object, live.Object)
+	return &change, nil
+}
+
+// describeChanges reports the changes users care the most about: images,
+// pod template annotations (e.g. the SCC in use) and configuration data.
+func describeChanges(desired, live map[string]interface{}) []string {
+	var details []string
+	for _, field := range []string{"initContainers", "containers"} {
+		desiredImages := containerImages(desired, field)
+		liveImages := containerImages(live, field)
+		for _, name := range sortedKeys(desiredImages) {
+			if liveImages[name] != desiredImages[name] {
+				details = append(details, fmt.Sprintf("image %s: %q -> %q", name, liveImages[name], desiredImages[name]))
+			}
+		}
+	}
+
+	desiredAnns, _, _ := unstructured.NestedStringMap(desired, "spec", "template", "metadata", "annotations")
+	liveAnns, _, _ := unstructured.NestedStringMap(live, "spec", "template", "metadata", "annotations")
+	for _, key := range sortedKeys(desiredAnns) {
+		if liveAnns[key] != desiredAnns[key] {
+			details = append(details, fmt.Sprintf("annotation %s: %q -> %q", key, liveAnns[key], desiredAnns[key]))
+		}
+	}
+
+	desiredData, _, _ := unstructured.NestedStringMap(desired, "data")
+	liveData, _, _ := unstructured.NestedStringMap(live, "data")
+	for _, key := range sortedKeys(desiredData) {
+		liveVal, ok := liveData[key]
+		if !ok {
+			details = append(details, fmt.Sprintf("data %s: added", key))
+		} else if liveVal != desiredData[key] {
+			details = append(details, fmt.Sprintf("data %s: changed", key))
+		}
+	}
+	return details
+}
+
+func containerImages(obj map[string]interface{}, field string) map[string]string {
+	ret := make(map[string]string)
+	containers, _, _ := unstructured.NestedSlice(obj, "spec", "template", "spec", field)
+	for _, item := range containers {
+		cnt, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(cnt, "name")
+		image, _, _ := unstructured.NestedString(cnt, "image")
+		ret[name] = image
+	}
+	return ret
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,227 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deploy
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/updaters"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+)
+
+func newTestOptions(plat platform.Platform, ver platform.Version) *options.Options {
+	return &options.Options{
+		ClusterPlatform: plat,
+		ClusterVersion:  ver,
+		UpdaterType:     updaters.RTE,
+		Replicas:        1,
+	}
+}
+
+// newInstalledEnv returns an Environment whose cluster holds all the objects deploy creates
+// with `commonOpts`, after `mutate` changed them.
+func newInstalledEnv(t *testing.T, commonOpts *options.Options, mutate func(obj client.Object)) *deployer.Environment {
+	t.Helper()
+	env := &deployer.Environment{
+		Ctx: context.Background(),
+		Log: testr.New(t),
+	}
+	var objs []client.Object
+	for _, component := range []string{manifests.ComponentAPI, updaterComponent(commonOpts.UpdaterType), manifests.ComponentSchedulerPlugin} {
+		compObjs, err := ComponentObjects(env, commonOpts, component)
+		if err != nil {
+			t.Fatalf("cannot render %q: %v", component, err)
+		}
+		objs = append(objs, compObjs...)
+	}
+	for _, obj := range objs {
+		if mutate != nil {
+			mutate(obj)
+		}
+	}
+	env.Cli = fake.NewClientBuilder().WithObjects(objs...).Build()
+	return env
+}
+
+func TestPlanUpgrade(t *testing.T) {
+	obsolete := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "obsolete",
+			Namespace: "tas-topology-updater",
+		},
+	}
+	manifests.StampObject(obsolete, updaterComponent(updaters.RTE), "")
+
+	testCases := []struct {
+		name            string
+		plat            platform.Platform
+		version         platform.Version
+		mutate          func(obj client.Object)
+		extraObjs       []client.Object
+		expectedChanges []string
+	}{
+		{
+			name: "no-op",
+		},
+		{
+			name: "image change",
+			mutate: func(obj client.Object) {
+				if ds, ok := obj.(*appsv1.DaemonSet); ok {
+					ds.Spec.Template.Spec.Containers[0].Image = "quay.io/old/rte:v0.1"
+				}
+			},
+			expectedChanges: []string{"update", "DaemonSet", `"quay.io/old/rte:v0.1" ->`},
+		},
+		{
+			name:    "annotation change",
+			plat:    platform.OpenShift,
+			version: "v4.16",
+			mutate: func(obj client.Object) {
+				if ds, ok := obj.(*appsv1.DaemonSet); ok {
+					for key := range ds.Spec.Template.Annotations {
+						ds.Spec.Template.Annotations[key] = "old-scc"
+					}
+				}
+			},
+			expectedChanges: []string{"update", "DaemonSet", `annotation`, `"old-scc" ->`},
+		},
+		{
+			name: "data change",
+			mutate: func(obj client.Object) {
+				if cm, ok := obj.(*corev1.ConfigMap); ok {
+					for key := range cm.Data {
+						cm.Data[key] = "old: data"
+					}
+				}
+			},
+			expectedChanges: []string{"update", "ConfigMap", "changed"},
+		},
+		{
+			name:            "removal",
+			extraObjs:       []client.Object{obsolete},
+			expectedChanges: []string{"delete", "ServiceAccount", "tas-topology-updater/obsolete"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			plat, version := tc.plat, tc.version
+			if plat == "" {
+				plat, version = platform.Kubernetes, "1.30"
+			}
+			commonOpts := newTestOptions(plat, version)
+			env := newInstalledEnv(t, commonOpts, tc.mutate)
+			for _, obj := range tc.extraObjs {
+				if err := env.Cli.Create(env.Ctx, obj.DeepCopyObject().(client.Object)); err != nil {
+					t.Fatalf("cannot create %q: %v", obj.GetName(), err)
+				}
+			}
+
+			plan, err := planUpgrade(env, commonOpts)
+			if err != nil {
+				t.Fatalf("plan failed: %v", err)
+			}
+			if !plan.API || plan.UpdaterType != updaters.RTE || !plan.Scheduler {
+				t.Errorf("installed components not detected: %+v", plan)
+			}
+			if len(tc.expectedChanges) == 0 {
+				if !plan.IsEmpty() {
+					t.Errorf("unexpected changes: %v", plan.Changes)
+				}
+				return
+			}
+			if len(plan.Changes) != 1 {
+				t.Fatalf("expected one change, got %v", plan.Changes)
+			}
+			desc := plan.Changes[0].String()
+			for _, expected := range tc.expectedChanges {
+				if !strings.Contains(desc, expected) {
+					t.Errorf("change %q does not contain %q", desc, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestComputeChangeMissing(t *testing.T) {
+	commonOpts := newTestOptions(platform.Kubernetes, "1.30")
+	env := newInstalledEnv(t, commonOpts, nil)
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "missing",
+			Namespace: "tas-topology-updater",
+		},
+	}
+	change, err := computeChange(env, "test", cm)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if change == nil || change.Action != UpgradeActionCreate {
+		t.Errorf("expected create, got %+v", change)
+	}
+}
+
+func TestUpgradeRemovesObsoleteObjects(t *testing.T) {
+	commonOpts := newTestOptions(platform.Kubernetes, "1.30")
+	obsolete := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "obsolete",
+			Namespace: "tas-topology-updater",
+		},
+	}
+	manifests.StampObject(obsolete, updaterComponent(updaters.RTE), "")
+	env := &deployer.Environment{
+		Ctx: context.Background(),
+		Cli: fake.NewClientBuilder().WithObjects(obsolete).Build(),
+		Log: testr.New(t),
+	}
+
+	plan := UpgradePlan{
+		Changes: []UpgradeChange{
+			{
+				Component:  updaterComponent(updaters.RTE),
+				Action:     UpgradeActionDelete,
+				APIVersion: "v1",
+				Kind:       "ServiceAccount",
+				Namespace:  obsolete.Namespace,
+				Name:       obsolete.Name,
+			},
+		},
+	}
+	if err := Upgrade(env, commonOpts, plan); err != nil {
+		t.Fatalf("upgrade failed: %v", err)
+	}
+	err := env.Cli.Get(env.Ctx, client.ObjectKeyFromObject(obsolete), &corev1.ServiceAccount{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("obsolete object not removed: %v", err)
+	}
+}
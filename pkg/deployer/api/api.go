@@ -17,13 +17,16 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
 	apimanifests "github.com/k8stopologyawareschedwg/deployer/pkg/manifests/api"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/objectwait"
 	apiwait "github.com/k8stopologyawareschedwg/deployer/pkg/objectwait/api"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 )
@@ -37,20 +40,24 @@ func Deploy(env *deployer.Environment, opts options.API) error {
 	env = env.WithName("API")
 	env.Log.Info("deploying topology-aware-scheduling API")
 
-	mf, err := apimanifests.NewWithOptions(options.Render{
-		Platform: opts.Platform,
-	})
+	objs, err := Creatable(env, opts)
 	if err != nil {
 		return err
 	}
 	env.Log.V(3).Info("API manifests loaded")
 
-	for _, wo := range apiwait.Creatable(mf, env.Cli, env.Log) {
-		if err := env.CreateObject(wo.Obj); err != nil {
-			return err
+	var dryRunErrs []error
+	for _, wo := range objs {
+		if err := env.CreateOrApplyObject(wo.Obj, opts.Apply); err != nil {
+			if !env.DryRun {
+				return err
+			}
+			// keep going to report all the rejected objects
+			dryRunErrs = append(dryRunErrs, env.DryRunError(wo.Obj, err))
+			continue
 		}
 
-		if wo.Wait == nil {
+		if env.DryRun || wo.Wait == nil {
 			continue
 		}
 
@@ -60,10 +67,28 @@ func Deploy(env *deployer.Environment, opts options.API) error {
 		}
 	}
 
+	if err := errors.Join(dryRunErrs...); err != nil {
+		return err
+	}
 	env.Log.Info("deployed topology-aware-scheduling API")
 	return nil
 }
 
+// Creatable returns the objects Deploy creates, in creation order.
+func Creatable(env *deployer.Environment, opts options.API) ([]objectwait.WaitableObject, error) {
+	mf, err := apimanifests.NewWithOptions(options.Render{
+		Platform: opts.Platform,
+	})
+	if err != nil {
+		return nil, err
+	}
+	objs := apiwait.Creatable(mf, env.Cli, env.Log)
+	for _, wo := range objs {
+		manifests.StampObject(wo.Obj, manifests.ComponentAPI, opts.InstallID)
+	}
+	return objs, nil
+}
+
 func Remove(env *deployer.Environment, opts options.API) error {
 	var err error
 	env = env.WithName("API")
@@ -77,13 +102,17 @@ func Remove(env *deployer.Environment, opts options.API) error {
 	}
 	env.Log.V(3).Info("API manifests loaded")
 
+	var dryRunErrs []error
 	for _, wo := range apiwait.Deletable(mf, env.Cli, env.Log) {
 		err = env.DeleteObject(wo.Obj)
 		if err != nil {
+			if env.DryRun {
+				dryRunErrs = append(dryRunErrs, env.DryRunRemoveError(wo.Obj, err))
+			}
 			continue
 		}
 
-		if wo.Wait == nil {
+		if env.DryRun || wo.Wait == nil {
 			continue
 		}
 
@@ -93,6 +122,9 @@ func Remove(env *deployer.Environment, opts options.API) error {
 		}
 	}
 
+	if err := errors.Join(dryRunErrs...); err != nil {
+		return err
+	}
 	env.Log.Info("removed topology-aware-scheduling API!")
 	return nil
 }
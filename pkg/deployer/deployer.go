@@ -21,15 +21,28 @@ import (
 
 	"github.com/go-logr/logr"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
 	"github.com/k8stopologyawareschedwg/deployer/pkg/clientutil"
 )
 
+// FieldManager is the field manager name used when applying objects server-side
+const FieldManager = "topology-aware-scheduling-deployer"
+
 type Environment struct {
 	Ctx context.Context
 	Cli client.Client
 	Log logr.Logger
+	// Journal, if set, records the changes made through this Environment
+	Journal *Journal
+	// DryRun makes all the changes server-side dry-run requests: they are
+	// validated and admitted by the apiserver, but never persisted.
+	DryRun bool
 }
 
 func (env *Environment) EnsureClient() error {
@@ -46,25 +59,120 @@ func (env *Environment) EnsureClient() error {
 
 func (env *Environment) WithName(name string) *Environment {
 	return &Environment{
-		Ctx: env.Ctx,
-		Cli: env.Cli,
-		Log: env.Log.WithName(name),
+		Ctx:     env.Ctx,
+		Cli:     env.Cli,
+		Log:     env.Log.WithName(name),
+		Journal: env.Journal,
+		DryRun:  env.DryRun,
+	}
+}
+
+// WithDryRun returns a copy of the Environment making server-side dry-run requests
+func (env *Environment) WithDryRun() *Environment {
+	return &Environment{
+		Ctx:     env.Ctx,
+		Cli:     env.Cli,
+		Log:     env.Log.WithValues("dryRun", "server"),
+		Journal: env.Journal,
+		DryRun:  true,
 	}
 }
 
 func (env Environment) CreateObject(obj client.Object) error {
 	objKind := obj.GetObjectKind().GroupVersionKind().Kind // shortcut
-	if err := env.Cli.Create(env.Ctx, obj); err != nil {
+	var opts []client.CreateOption
+	if env.DryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err := env.Cli.Create(env.Ctx, obj, opts...); err != nil {
 		env.Log.Info("error creating", "kind", objKind, "name", obj.GetName(), "error", err)
 		return err
 	}
 	env.Log.Info("created", "kind", objKind, "name", obj.GetName())
+	if env.Journal != nil && !env.DryRun {
+		env.Journal.record(env.kindForObject(obj), obj, nil)
+	}
+	return nil
+}
+
+// ApplyObject creates the object if missing, or reconciles it in place using server-side apply.
+// The deployer takes ownership of all the fields it renders, forcing over conflicting managers.
+func (env Environment) ApplyObject(obj client.Object) error {
+	objKind := obj.GetObjectKind().GroupVersionKind().Kind // shortcut
+	if err := env.applyObject(obj); err != nil {
+		env.Log.Info("error applying", "kind", objKind, "name", obj.GetName(), "error", err)
+		return err
+	}
+	env.Log.Info("applied", "kind", objKind, "name", obj.GetName())
 	return nil
 }
 
+// CreateOrApplyObject creates the object, or applies it if `apply` is true.
+func (env Environment) CreateOrApplyObject(obj client.Object, apply bool) error {
+	if apply {
+		return env.ApplyObject(obj)
+	}
+	return env.CreateObject(obj)
+}
+
+func (env Environment) applyObject(obj client.Object) error {
+	gvk, err := env.gvkForObject(obj)
+	if err != nil {
+		return err
+	}
+	var previous *unstructured.Unstructured
+	if env.Journal != nil && !env.DryRun {
+		previous, err = env.snapshotObject(obj)
+		if err != nil {
+			return err
+		}
+	}
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	uobj := &unstructured.Unstructured{Object: data}
+	uobj.SetGroupVersionKind(gvk)
+	// server-populated fields, we must not claim ownership of them
+	uobj.SetResourceVersion("")
+	uobj.SetManagedFields(nil)
+	unstructured.RemoveNestedField(uobj.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(uobj.Object, "status")
+
+	opts := []client.ApplyOption{client.FieldOwner(FieldManager), client.ForceOwnership}
+	if env.DryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	err = env.Cli.Apply(env.Ctx, client.ApplyConfigurationFromUnstructured(uobj), opts...)
+	if err != nil {
+		return err
+	}
+	if env.Journal != nil && !env.DryRun {
+		env.Journal.record(gvk.Kind, obj, previous)
+	}
+	// keep the same semantic of Create: reflect the server state into the object
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(uobj.Object, obj)
+}
+
+func (env Environment) gvkForObject(obj client.Object) (schema.GroupVersionKind, error) {
+	return apiutil.GVKForObject(obj, env.Cli.Scheme())
+}
+
+func (env Environment) kindForObject(obj client.Object) string {
+	gvk, err := env.gvkForObject(obj)
+	if err != nil {
+		return obj.GetObjectKind().GroupVersionKind().Kind
+	}
+	return gvk.Kind
+}
+
 func (env Environment) DeleteObject(obj client.Object) error {
 	objKind := obj.GetObjectKind().GroupVersionKind().Kind // shortcut
-	if err := env.Cli.Delete(env.Ctx, obj); err != nil {
+	var opts []client.DeleteOption
+	if env.DryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err := env.Cli.Delete(env.Ctx, obj, opts...); err != nil {
 		env.Log.Info("error deleting", "kind", objKind, "name", obj.GetName(), "error", err)
 		return err
 	}
@@ -0,0 +1,294 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deployer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestApplyObject(t *testing.T) {
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "existing",
+			Namespace: "foo",
+		},
+		Data: map[string]string{
+			"key": "old",
+		},
+	}
+
+	env := Environment{
+		Ctx: context.Background(),
+		Cli: fake.NewClientBuilder().WithObjects(existing).Build(),
+		Log: testr.New(t),
+	}
+
+	for _, name := range []string{"existing", "missing"} {
+		t.Run(name, func(t *testing.T) {
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "foo",
+				},
+				Data: map[string]string{
+					"key": "new",
+				},
+			}
+			if err := env.ApplyObject(cm); err != nil {
+				t.Fatalf("apply failed: %v", err)
+			}
+
+			got := corev1.ConfigMap{}
+			if err := env.Cli.Get(env.Ctx, client.ObjectKeyFromObject(cm), &got); err != nil {
+				t.Fatalf("get failed: %v", err)
+			}
+			if got.Data["key"] != "new" {
+				t.Errorf("object not reconciled: %v", got.Data)
+			}
+		})
+	}
+}
+
+func TestCreateObjectAlreadyExists(t *testing.T) {
+	existing := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+	env := Environment{
+		Ctx: context.Background(),
+		Cli: fake.NewClientBuilder().WithObjects(existing).Build(),
+		Log: testr.New(t),
+	}
+
+	if err := env.CreateOrApplyObject(existing.DeepCopy(), false); err == nil {
+		t.Errorf("create unexpectedly succeeded on existing object")
+	}
+	if err := env.CreateOrApplyObject(existing.DeepCopy(), true); err != nil {
+		t.Errorf("apply failed on existing object: %v", err)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "existing",
+			Namespace: "foo",
+		},
+		Data: map[string]string{
+			"key": "old",
+		},
+	}
+
+	env := Environment{
+		Ctx:     context.Background(),
+		Cli:     fake.NewClientBuilder().WithObjects(existing).Build(),
+		Log:     testr.New(t),
+		Journal: NewJournal(),
+	}
+
+	created := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "created",
+			Namespace: "foo",
+		},
+	}
+	if err := env.WithName("test").CreateObject(created); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	mutated := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "existing",
+			Namespace: "foo",
+		},
+		Data: map[string]string{
+			"key": "new",
+		},
+	}
+	if err := env.ApplyObject(mutated); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if env.Journal.Len() != 2 {
+		t.Fatalf("journal entries got %d expected 2", env.Journal.Len())
+	}
+
+	results := env.Rollback()
+	expected := []RollbackResult{
+		{Action: RollbackRestored, Kind: "ConfigMap", Namespace: "foo", Name: "existing"},
+		{Action: RollbackDeleted, Kind: "ConfigMap", Namespace: "foo", Name: "created"},
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("rollback results got %v expected %v", results, expected)
+	}
+	for idx := range expected {
+		if results[idx] != expected[idx] {
+			t.Errorf("rollback result %d got %+v expected %+v", idx, results[idx], expected[idx])
+		}
+	}
+	if env.Journal.Len() != 0 {
+		t.Errorf("journal not empty after rollback")
+	}
+
+	got := corev1.ConfigMap{}
+	err := env.Cli.Get(env.Ctx, client.ObjectKeyFromObject(created), &got)
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("created object not deleted: %v", err)
+	}
+	err = env.Cli.Get(env.Ctx, client.ObjectKeyFromObject(existing), &got)
+	if err != nil {
+		t.Fatalf("cannot get the restored object: %v", err)
+	}
+	if got.Data["key"] != "old" {
+		t.Errorf("mutated object not restored: %v", got.Data)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	env := (&Environment{
+		Ctx:     context.Background(),
+		Cli:     fake.NewClientBuilder().Build(),
+		Log:     testr.New(t),
+		Journal: NewJournal(),
+	}).WithDryRun()
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dryrun",
+			Namespace: "foo",
+		},
+	}
+	if err := env.WithName("test").CreateObject(cm); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	err := env.Cli.Get(env.Ctx, client.ObjectKeyFromObject(cm), &corev1.ConfigMap{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("object persisted in dry-run mode: %v", err)
+	}
+	if env.Journal.Len() != 0 {
+		t.Errorf("dry-run changes recorded in the journal")
+	}
+}
+
+func TestDryRunError(t *testing.T) {
+	env := Environment{
+		Ctx: context.Background(),
+		Cli: fake.NewClientBuilder().Build(),
+		Log: testr.New(t),
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cm",
+			Namespace: "foo",
+		},
+	}
+
+	type testCase struct {
+		name               string
+		err                error
+		expectError        bool
+		expectNotValidated bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "no error",
+		},
+		{
+			name:               "namespace not found",
+			err:                apierrors.NewNotFound(corev1.Resource("namespaces"), "foo"),
+			expectError:        true,
+			expectNotValidated: true,
+		},
+		{
+			name:        "other namespace not found",
+			err:         apierrors.NewNotFound(corev1.Resource("namespaces"), "bar"),
+			expectError: true,
+		},
+		{
+			name:        "rejected",
+			err:         apierrors.NewForbidden(corev1.Resource("configmaps"), "cm", errors.New("denied by webhook")),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := env.DryRunError(cm, tc.err)
+			if tc.expectError && err == nil {
+				t.Fatalf("unexpected success")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err != nil && !strings.Contains(err.Error(), "ConfigMap foo/cm") {
+				t.Errorf("error does not identify the object: %v", err)
+			}
+			if got := errors.Is(err, ErrNotValidated); got != tc.expectNotValidated {
+				t.Errorf("not validated: got %v expected %v (error: %v)", got, tc.expectNotValidated, err)
+			}
+		})
+	}
+}
+
+func TestDryRunMissingNamespace(t *testing.T) {
+	// the fake client doesn't check namespaces, unlike the apiserver: emulate it
+	cli := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if ns := obj.GetNamespace(); ns != "" {
+				if err := cli.Get(ctx, client.ObjectKey{Name: ns}, &corev1.Namespace{}); err != nil {
+					return apierrors.NewNotFound(corev1.Resource("namespaces"), ns)
+				}
+			}
+			return cli.Create(ctx, obj, opts...)
+		},
+	}).Build()
+	env := (&Environment{
+		Ctx: context.Background(),
+		Cli: cli,
+		Log: testr.New(t),
+	}).WithDryRun()
+
+	nodes := []GraphNode{
+		{
+			Obj: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foo"}},
+		},
+		{
+			Obj:  &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "foo"}},
+			Deps: []int{0},
+		},
+	}
+	err := env.CreateOrApplyGraph(nodes, false, 1)
+	if !errors.Is(err, ErrNotValidated) {
+		t.Fatalf("dry-run reported as validated: %v", err)
+	}
+	if !strings.Contains(err.Error(), "ConfigMap foo/cm") {
+		t.Errorf("error does not identify the object: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deployer
+
+import (
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrNotValidated marks the objects the apiserver could not validate in dry-run mode.
+var ErrNotValidated = errors.New("not validated")
+
+// DryRunError qualifies the error of a dry-run request with the object it refers to.
+// If the request failed only because the namespace of the object doesn't exist, the error
+// wraps ErrNotValidated: dry-run requests persist nothing, including the namespaces created
+// before, so the object was never checked and the dry-run can't be reported as successful.
+func (env Environment) DryRunError(obj client.Object, err error) error {
+	if err == nil {
+		return nil
+	}
+	kind := env.kindForObject(obj)
+	name := obj.GetName()
+	if obj.GetNamespace() != "" {
+		name = obj.GetNamespace() + "/" + name
+	}
+	if isNamespaceNotFound(err, obj.GetNamespace()) {
+		env.Log.Info("cannot validate, namespace not found", "kind", kind, "name", name)
+		return fmt.Errorf("%s %s: %w: namespace %q not found", kind, name, ErrNotValidated, obj.GetNamespace())
+	}
+	return fmt.Errorf("%s %s: %w", kind, name, err)
+}
+
+// DryRunRemoveError is like DryRunError, but ignores missing objects, including the ones
+// in missing namespaces: there is nothing to remove.
+func (env Environment) DryRunRemoveError(obj client.Object, err error) error {
+	return env.DryRunError(obj, client.IgnoreNotFound(err))
+}
+
+func isNamespaceNotFound(err error, namespace string) bool {
+	if namespace == "" || !apierrors.IsNotFound(err) {
+		return false
+	}
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return false
+	}
+	details := status.Status().Details
+	return details != nil && details.Kind == "namespaces" && details.Name == namespace
+}
@@ -0,0 +1,139 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deployer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GraphNode is an object to create, together with the objects which must be
+// created, and waited for, before it.
+type GraphNode struct {
+	// Name is the name of the logger used for the node, usually the component name
+	Name string
+	Obj  client.Object
+	// Wait, if set, blocks until the object is ready. The node is complete only
+	// after Wait returns.
+	Wait func(ctx context.Context) error
+	// Deps are the indexes of the nodes which must be complete before this node starts
+	Deps []int
+}
+
+// CreateOrApplyGraph creates, or applies if `apply` is true, the objects of the nodes.
+// Each node starts once all its dependencies are complete; up to `parallelism` nodes run
+// concurrently. Among the nodes ready to start, the ones listed first start first, so
+// with parallelism 1 the nodes run in the given order if it satisfies the dependencies.
+// A failed node does not stop the independent nodes, but its dependents are skipped.
+// Returns the errors of all the failed nodes.
+func (env *Environment) CreateOrApplyGraph(nodes []GraphNode, apply bool, parallelism int) error {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	pending := make([]int, len(nodes))
+	dependents := make([][]int, len(nodes))
+	var ready []int
+	for idx, node := range nodes {
+		for _, dep := range node.Deps {
+			if dep < 0 || dep >= len(nodes) || dep == idx {
+				return fmt.Errorf("node %d %q: invalid dependency %d", idx, node.Obj.GetName(), dep)
+			}
+			dependents[dep] = append(dependents[dep], idx)
+		}
+		pending[idx] = len(node.Deps)
+		if pending[idx] == 0 {
+			ready = append(ready, idx)
+		}
+	}
+
+	type result struct {
+		idx int
+		err error
+	}
+	results := make(chan result)
+	skipped := make([]bool, len(nodes))
+	running, done := 0, 0
+	var errs []error
+	for done < len(nodes) {
+		for running < parallelism && len(ready) > 0 {
+			idx := ready[0]
+			ready = ready[1:]
+			running++
+			go func(idx int) {
+				results <- result{idx: idx, err: env.WithName(nodes[idx].Name).runGraphNode(nodes[idx], apply)}
+			}(idx)
+		}
+		if running == 0 {
+			errs = append(errs, fmt.Errorf("dependency cycle: %d objects cannot be created", len(nodes)-done))
+			break
+		}
+
+		res := <-results
+		running--
+		done++
+		if res.err != nil {
+			errs = append(errs, res.err)
+			done += env.skipDependents(nodes, dependents, skipped, res.idx)
+			continue
+		}
+		for _, dep := range dependents[res.idx] {
+			pending[dep]--
+			if pending[dep] == 0 && !skipped[dep] {
+				ready = append(ready, dep)
+			}
+		}
+		sort.Ints(ready)
+	}
+	return errors.Join(errs...)
+}
+
+func (env *Environment) runGraphNode(node GraphNode, apply bool) error {
+	if err := env.CreateOrApplyObject(node.Obj, apply); err != nil {
+		if env.DryRun {
+			return env.DryRunError(node.Obj, err)
+		}
+		return err
+	}
+	if env.DryRun || node.Wait == nil {
+		return nil
+	}
+	return node.Wait(env.Ctx)
+}
+
+// skipDependents marks as skipped all the nodes depending, directly or not, on the node `idx`.
+// Returns how many nodes were skipped.
+func (env *Environment) skipDependents(nodes []GraphNode, dependents [][]int, skipped []bool, idx int) int {
+	count := 0
+	queue := append([]int{}, dependents[idx]...)
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if skipped[cur] {
+			continue
+		}
+		skipped[cur] = true
+		count++
+		env.Log.Info("skipped, dependency failed", "name", nodes[cur].Obj.GetName(), "dependency", nodes[idx].Obj.GetName())
+		queue = append(queue, dependents[cur]...)
+	}
+	return count
+}
@@ -0,0 +1,159 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type graphRecorder struct {
+	lock  sync.Mutex
+	order []string
+}
+
+func (gr *graphRecorder) node(name string, deps ...int) GraphNode {
+	return GraphNode{
+		Name: "test",
+		Obj:  &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}},
+		Wait: func(ctx context.Context) error {
+			gr.lock.Lock()
+			defer gr.lock.Unlock()
+			gr.order = append(gr.order, name)
+			return nil
+		},
+		Deps: deps,
+	}
+}
+
+func TestCreateOrApplyGraphOrder(t *testing.T) {
+	env := Environment{
+		Ctx: context.Background(),
+		Cli: fake.NewClientBuilder().Build(),
+		Log: testr.New(t),
+	}
+	gr := &graphRecorder{}
+	nodes := []GraphNode{
+		gr.node("ns-c", 2),
+		gr.node("ns-a"),
+		gr.node("ns-b", 1),
+		gr.node("ns-d"),
+	}
+	if err := env.CreateOrApplyGraph(nodes, false, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"ns-a", "ns-b", "ns-c", "ns-d"}
+	if !reflect.DeepEqual(gr.order, expected) {
+		t.Errorf("got order %v expected %v", gr.order, expected)
+	}
+}
+
+func TestCreateOrApplyGraphParallel(t *testing.T) {
+	env := Environment{
+		Ctx: context.Background(),
+		Cli: fake.NewClientBuilder().Build(),
+		Log: testr.New(t),
+	}
+
+	// each wait completes only once all the waits are running
+	var started sync.WaitGroup
+	started.Add(3)
+	barrier := func(ctx context.Context) error {
+		started.Done()
+		done := make(chan struct{})
+		go func() {
+			started.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+			return nil
+		case <-time.After(5 * time.Second):
+			return fmt.Errorf("waits not running concurrently")
+		}
+	}
+	var nodes []GraphNode
+	for _, name := range []string{"ns-a", "ns-b", "ns-c"} {
+		nodes = append(nodes, GraphNode{
+			Name: "test",
+			Obj:  &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}},
+			Wait: barrier,
+		})
+	}
+	if err := env.CreateOrApplyGraph(nodes, false, 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCreateOrApplyGraphFailure(t *testing.T) {
+	existing := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-a"}}
+	env := Environment{
+		Ctx: context.Background(),
+		Cli: fake.NewClientBuilder().WithObjects(existing).Build(),
+		Log: testr.New(t),
+	}
+	gr := &graphRecorder{}
+	nodes := []GraphNode{
+		gr.node("ns-a"),    // fails: already exists
+		gr.node("ns-b", 0), // skipped
+		gr.node("ns-c", 1), // skipped
+		gr.node("ns-d"),
+	}
+	err := env.CreateOrApplyGraph(nodes, false, 2)
+	if !apierrors.IsAlreadyExists(err) {
+		t.Fatalf("expected AlreadyExists error, got %v", err)
+	}
+	if !reflect.DeepEqual(gr.order, []string{"ns-d"}) {
+		t.Errorf("unexpected completed nodes: %v", gr.order)
+	}
+	for _, name := range []string{"ns-b", "ns-c"} {
+		ns := corev1.Namespace{}
+		err := env.Cli.Get(env.Ctx, client.ObjectKey{Name: name}, &ns)
+		if !apierrors.IsNotFound(err) {
+			t.Errorf("skipped node %q created: %v", name, err)
+		}
+	}
+}
+
+func TestCreateOrApplyGraphCycle(t *testing.T) {
+	env := Environment{
+		Ctx: context.Background(),
+		Cli: fake.NewClientBuilder().Build(),
+		Log: testr.New(t),
+	}
+	gr := &graphRecorder{}
+	nodes := []GraphNode{
+		gr.node("ns-a", 1),
+		gr.node("ns-b", 0),
+	}
+	if err := env.CreateOrApplyGraph(nodes, false, 2); err == nil {
+		t.Fatalf("expected error on dependency cycle")
+	}
+}
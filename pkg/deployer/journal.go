@@ -0,0 +1,156 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deployer
+
+import (
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Journal records the objects created or mutated through an Environment,
+// so the changes can be rolled back if a later step fails.
+type Journal struct {
+	lock    sync.Mutex
+	entries []journalEntry
+}
+
+type journalEntry struct {
+	kind string
+	obj  client.Object
+	// previous is the state before the mutation, nil if the object was created
+	previous *unstructured.Unstructured
+}
+
+func NewJournal() *Journal {
+	return &Journal{}
+}
+
+func (jr *Journal) Len() int {
+	jr.lock.Lock()
+	defer jr.lock.Unlock()
+	return len(jr.entries)
+}
+
+func (jr *Journal) record(kind string, obj client.Object, previous *unstructured.Unstructured) {
+	jr.lock.Lock()
+	defer jr.lock.Unlock()
+	jr.entries = append(jr.entries, journalEntry{
+		kind:     kind,
+		obj:      obj,
+		previous: previous,
+	})
+}
+
+// drain returns the recorded entries, latest first, and empties the journal
+func (jr *Journal) drain() []journalEntry {
+	jr.lock.Lock()
+	defer jr.lock.Unlock()
+	ret := make([]journalEntry, 0, len(jr.entries))
+	for idx := len(jr.entries) - 1; idx >= 0; idx-- {
+		ret = append(ret, jr.entries[idx])
+	}
+	jr.entries = nil
+	return ret
+}
+
+type RollbackAction string
+
+const (
+	RollbackDeleted  RollbackAction = "deleted"
+	RollbackRestored RollbackAction = "restored"
+)
+
+type RollbackResult struct {
+	Action    RollbackAction
+	Kind      string
+	Namespace string
+	Name      string
+	Error     error
+}
+
+// Rollback undoes the changes recorded in the journal, in reverse order:
+// created objects are deleted, mutated objects are restored to their previous state.
+// Rollback keeps going on errors, and reports the outcome for each object.
+func (env *Environment) Rollback() []RollbackResult {
+	if env.Journal == nil {
+		return nil
+	}
+	var results []RollbackResult
+	for _, entry := range env.Journal.drain() {
+		res := RollbackResult{
+			Kind:      entry.kind,
+			Namespace: entry.obj.GetNamespace(),
+			Name:      entry.obj.GetName(),
+		}
+		if entry.previous == nil {
+			res.Action = RollbackDeleted
+			res.Error = client.IgnoreNotFound(env.Cli.Delete(env.Ctx, entry.obj))
+		} else {
+			res.Action = RollbackRestored
+			res.Error = env.restoreObject(entry.previous)
+		}
+		if res.Error != nil {
+			env.Log.Info("error rolling back", "action", res.Action, "kind", res.Kind, "namespace", res.Namespace, "name", res.Name, "error", res.Error)
+		} else {
+			env.Log.Info("rolled back", "action", res.Action, "kind", res.Kind, "namespace", res.Namespace, "name", res.Name)
+		}
+		results = append(results, res)
+	}
+	return results
+}
+
+func (env *Environment) restoreObject(previous *unstructured.Unstructured) error {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(previous.GroupVersionKind())
+	err := env.Cli.Get(env.Ctx, client.ObjectKeyFromObject(previous), current)
+	if apierrors.IsNotFound(err) {
+		restored := previous.DeepCopy()
+		restored.SetResourceVersion("")
+		restored.SetUID("")
+		restored.SetManagedFields(nil)
+		return env.Cli.Create(env.Ctx, restored)
+	}
+	if err != nil {
+		return err
+	}
+	restored := previous.DeepCopy()
+	restored.SetResourceVersion(current.GetResourceVersion())
+	restored.SetManagedFields(nil)
+	return env.Cli.Update(env.Ctx, restored)
+}
+
+// snapshotObject returns the current state of the object, or nil if it doesn't exist
+func (env Environment) snapshotObject(obj client.Object) (*unstructured.Unstructured, error) {
+	gvk, err := env.gvkForObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(gvk)
+	err = env.Cli.Get(env.Ctx, client.ObjectKeyFromObject(obj), current)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return current, nil
+}
@@ -17,13 +17,16 @@
 package sched
 
 import (
+	"errors"
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
 	schedmanifests "github.com/k8stopologyawareschedwg/deployer/pkg/manifests/sched"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/objectwait"
 	schedwait "github.com/k8stopologyawareschedwg/deployer/pkg/objectwait/sched"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 )
@@ -37,25 +40,24 @@ func Deploy(env *deployer.Environment, opts options.Scheduler) error {
 	env = env.WithName("SCD")
 	env.Log.Info("deploying topology-aware-scheduling scheduler plugin")
 
-	mf, err := schedmanifests.NewWithOptions(options.Render{
-		Platform: opts.Platform,
-	})
-	if err != nil {
-		return err
-	}
-
-	mf, err = mf.Render(env.Log, opts)
+	objs, err := Creatable(env, opts)
 	if err != nil {
 		return err
 	}
 	env.Log.V(3).Info("manifests loaded")
 
-	for _, wo := range schedwait.Creatable(mf, env.Cli, env.Log) {
-		if err := env.CreateObject(wo.Obj); err != nil {
-			return err
+	var dryRunErrs []error
+	for _, wo := range objs {
+		if err := env.CreateOrApplyObject(wo.Obj, opts.Apply); err != nil {
+			if !env.DryRun {
+				return err
+			}
+			// keep going to report all the rejected objects
+			dryRunErrs = append(dryRunErrs, env.DryRunError(wo.Obj, err))
+			continue
 		}
 
-		if !opts.WaitCompletion || wo.Wait == nil {
+		if env.DryRun || !opts.WaitCompletion || wo.Wait == nil {
 			continue
 		}
 
@@ -65,10 +67,33 @@ func Deploy(env *deployer.Environment, opts options.Scheduler) error {
 		}
 	}
 
+	if err := errors.Join(dryRunErrs...); err != nil {
+		return err
+	}
 	env.Log.Info("deployed topology-aware-scheduling scheduler plugin")
 	return nil
 }
 
+// Creatable returns the objects Deploy creates, in creation order.
+func Creatable(env *deployer.Environment, opts options.Scheduler) ([]objectwait.WaitableObject, error) {
+	mf, err := schedmanifests.NewWithOptions(options.Render{
+		Platform: opts.Platform,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mf, err = mf.Render(env.Log, opts)
+	if err != nil {
+		return nil, err
+	}
+	objs := schedwait.Creatable(mf, env.Cli, env.Log)
+	for _, wo := range objs {
+		manifests.StampObject(wo.Obj, manifests.ComponentSchedulerPlugin, opts.InstallID)
+	}
+	return objs, nil
+}
+
 func Remove(env *deployer.Environment, opts options.Scheduler) error {
 	var err error
 	env = env.WithName("SCD")
@@ -87,13 +112,17 @@ func Remove(env *deployer.Environment, opts options.Scheduler) error {
 	}
 	env.Log.V(3).Info("manifests loaded")
 
+	var dryRunErrs []error
 	for _, wo := range schedwait.Deletable(mf, env.Cli, env.Log) {
 		err = env.DeleteObject(wo.Obj)
 		if err != nil {
+			if env.DryRun {
+				dryRunErrs = append(dryRunErrs, env.DryRunRemoveError(wo.Obj, err))
+			}
 			continue
 		}
 
-		if !opts.WaitCompletion || wo.Wait == nil {
+		if env.DryRun || !opts.WaitCompletion || wo.Wait == nil {
 			continue
 		}
 
@@ -103,6 +132,9 @@ func Remove(env *deployer.Environment, opts options.Scheduler) error {
 		}
 	}
 
+	if err := errors.Join(dryRunErrs...); err != nil {
+		return err
+	}
 	env.Log.Info("removed topology-aware-scheduling scheduler plugin")
 	return nil
 }
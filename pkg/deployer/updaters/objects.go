@@ -19,6 +19,8 @@ package updaters
 import (
 	"fmt"
 
+	"github.com/go-logr/logr"
+
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
@@ -56,7 +58,20 @@ func GetObjects(opts options.Updater, updaterType, namespace string) ([]client.O
 	return nil, fmt.Errorf("unsupported updater: %q", updaterType)
 }
 
-func getCreatableObjects(env *deployer.Environment, opts options.Updater, updaterType, namespace string) ([]objectwait.WaitableObject, error) {
+// GetCreatableObjects is like GetObjects, but returns the objects in the order Deploy creates them.
+func GetCreatableObjects(opts options.Updater, updaterType, namespace string) ([]client.Object, error) {
+	wos, err := getCreatableObjects(nil, logr.Discard(), opts, updaterType, namespace)
+	if err != nil {
+		return nil, err
+	}
+	objs := make([]client.Object, 0, len(wos))
+	for _, wo := range wos {
+		objs = append(objs, wo.Obj)
+	}
+	return objs, nil
+}
+
+func getCreatableObjects(cli client.Client, log logr.Logger, opts options.Updater, updaterType, namespace string) ([]objectwait.WaitableObject, error) {
 	if updaterType == RTE {
 		mf, err := rtemanifests.NewWithOptions(renderOptionsFrom(opts, namespace))
 		if err != nil {
@@ -66,7 +81,7 @@ func getCreatableObjects(env *deployer.Environment, opts options.Updater, update
 		if err != nil {
 			return nil, err
 		}
-		return rtewait.Creatable(ret, env.Cli, env.Log), nil
+		return rtewait.Creatable(ret, cli, log), nil
 	}
 	if updaterType == NFD {
 		mf, err := nfdmanifests.NewWithOptions(renderOptionsFrom(opts, namespace))
@@ -77,7 +92,7 @@ func getCreatableObjects(env *deployer.Environment, opts options.Updater, update
 		if err != nil {
 			return nil, err
 		}
-		return nfdwait.Creatable(ret, env.Cli, env.Log), nil
+		return nfdwait.Creatable(ret, cli, log), nil
 	}
 	return nil, fmt.Errorf("unsupported updater: %q", updaterType)
 }
@@ -18,6 +18,7 @@ package updaters
 
 import (
 	"context"
+	"errors"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -38,26 +39,25 @@ func Deploy(env *deployer.Environment, updaterType string, opts options.Updater)
 	env = env.WithName(updaterType)
 	env.Log.Info("deploying topology-aware-scheduling topology updater")
 
-	ns, namespace, err := SetupNamespace(updaterType)
-	if err != nil {
-		return err
-	}
-
-	objs, err := getCreatableObjects(env, opts, updaterType, namespace)
+	objs, err := Creatable(env, updaterType, opts)
 	if err != nil {
 		return err
 	}
 
 	env.Log.V(3).Info("manifests loaded")
 
-	objs = append([]objectwait.WaitableObject{{Obj: ns}}, objs...)
-
+	var dryRunErrs []error
 	for _, wo := range objs {
-		if err := env.CreateObject(wo.Obj); err != nil {
-			return err
+		if err := env.CreateOrApplyObject(wo.Obj, opts.Apply); err != nil {
+			if !env.DryRun {
+				return err
+			}
+			// keep going to report all the rejected objects
+			dryRunErrs = append(dryRunErrs, env.DryRunError(wo.Obj, err))
+			continue
 		}
 
-		if !opts.WaitCompletion || wo.Wait == nil {
+		if env.DryRun || !opts.WaitCompletion || wo.Wait == nil {
 			continue
 		}
 
@@ -67,10 +67,32 @@ func Deploy(env *deployer.Environment, updaterType string, opts options.Updater)
 		}
 	}
 
+	if err := errors.Join(dryRunErrs...); err != nil {
+		return err
+	}
 	env.Log.Info("deployed topology-aware-scheduling topology updater!")
 	return nil
 }
 
+// Creatable returns the objects Deploy creates, including the namespace, in creation order.
+func Creatable(env *deployer.Environment, updaterType string, opts options.Updater) ([]objectwait.WaitableObject, error) {
+	ns, namespace, err := SetupNamespace(updaterType)
+	if err != nil {
+		return nil, err
+	}
+
+	objs, err := getCreatableObjects(env.Cli, env.Log, opts, updaterType, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	objs = append([]objectwait.WaitableObject{{Obj: ns}}, objs...)
+	for _, wo := range objs {
+		manifests.StampObject(wo.Obj, updaterTypeAsComponent(updaterType), opts.InstallID)
+	}
+	return objs, nil
+}
+
 func Remove(env *deployer.Environment, updaterType string, opts options.Updater) error {
 	var err error
 	env = env.WithName(updaterType)
@@ -93,13 +115,17 @@ func Remove(env *deployer.Environment, updaterType string, opts options.Updater)
 		Obj:  ns,
 		Wait: func(ctx context.Context) error { return wait.With(env.Cli, env.Log).ForNamespaceDeleted(ctx, ns.Name) },
 	})
+	var dryRunErrs []error
 	for _, wo := range objs {
 		err = env.DeleteObject(wo.Obj)
 		if err != nil {
+			if env.DryRun {
+				dryRunErrs = append(dryRunErrs, env.DryRunRemoveError(wo.Obj, err))
+			}
 			continue
 		}
 
-		if !opts.WaitCompletion || wo.Wait == nil {
+		if env.DryRun || !opts.WaitCompletion || wo.Wait == nil {
 			continue
 		}
 
@@ -109,6 +135,9 @@ func Remove(env *deployer.Environment, updaterType string, opts options.Updater)
 		}
 	}
 
+	if err := errors.Join(dryRunErrs...); err != nil {
+		return err
+	}
 	env.Log.Info("removed topology-aware-scheduling topology updater!")
 	return nil
 }
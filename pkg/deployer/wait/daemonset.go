@@ -32,11 +32,12 @@ func (wt Waiter) ForDaemonSetReadyByKey(ctx context.Context, key ObjectKey) (*ap
 			return false, err
 		}
 
-		if !AreDaemonSetPodsReady(&updatedDs.Status) {
+		if !AreDaemonSetPodsReady(&updatedDs.Status) || !IsDaemonSetRolloutComplete(updatedDs) {
 			wt.Log.Info("daemonset not ready",
 				"key", key.String(),
 				"desired", updatedDs.Status.DesiredNumberScheduled,
 				"current", updatedDs.Status.CurrentNumberScheduled,
+				"updated", updatedDs.Status.UpdatedNumberScheduled,
 				"ready", updatedDs.Status.NumberReady)
 			return false, nil
 		}
@@ -56,6 +57,14 @@ func AreDaemonSetPodsReady(newStatus *appsv1.DaemonSetStatus) bool {
 		newStatus.DesiredNumberScheduled == newStatus.NumberReady
 }
 
+// IsDaemonSetRolloutComplete tells if the controller observed the latest spec
+// of the daemonset and all the scheduled pods run the latest pod template.
+// Readiness alone is not enough on updates, because old pods are still ready.
+func IsDaemonSetRolloutComplete(ds *appsv1.DaemonSet) bool {
+	return ds.Status.ObservedGeneration >= ds.Generation &&
+		ds.Status.UpdatedNumberScheduled == ds.Status.DesiredNumberScheduled
+}
+
 func (wt Waiter) ForDaemonSetDeleted(ctx context.Context, namespace, name string) error {
 	return k8swait.PollUntilContextTimeout(ctx, wt.PollInterval, wt.PollTimeout, true, func(fctx context.Context) (bool, error) {
 		obj := appsv1.DaemonSet{}
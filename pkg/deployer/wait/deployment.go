@@ -33,9 +33,11 @@ func (wt Waiter) ForDeploymentCompleteByKey(ctx context.Context, key ObjectKey,
 			return false, err
 		}
 
-		if !areDeploymentReplicasAvailable(&updatedDp.Status, replicas) {
+		if updatedDp.Status.ObservedGeneration < updatedDp.Generation || !areDeploymentReplicasAvailable(&updatedDp.Status, replicas) {
 			wt.Log.Info("deployment not complete",
 				"key", key.String(),
+				"generation", updatedDp.Generation,
+				"observedGeneration", updatedDp.Status.ObservedGeneration,
 				"replicas", updatedDp.Status.Replicas,
 				"updated", updatedDp.Status.UpdatedReplicas,
 				"available", updatedDp.Status.AvailableReplicas)
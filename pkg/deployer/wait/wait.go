@@ -28,6 +28,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	k8swait "k8s.io/apimachinery/pkg/util/wait"
 )
@@ -109,6 +110,17 @@ func (wt Waiter) ForNamespaceDeleted(ctx context.Context, namespace string) erro
 	})
 }
 
+// ForObjectDeleted waits for the object, of any kind, to be gone.
+func (wt Waiter) ForObjectDeleted(ctx context.Context, obj *unstructured.Unstructured) error {
+	key := ObjectKeyFromObject(obj)
+	return k8swait.PollUntilContextTimeout(ctx, wt.PollInterval, wt.PollTimeout, true, func(fctx context.Context) (bool, error) {
+		live := unstructured.Unstructured{}
+		live.SetGroupVersionKind(obj.GroupVersionKind())
+		err := wt.Cli.Get(fctx, key.AsKey(), &live)
+		return deletionStatusFromError(wt.Log, obj.GetKind(), key, err)
+	})
+}
+
 func deletionStatusFromError(logger logr.Logger, kind string, key ObjectKey, err error) (bool, error) {
 	if err == nil {
 		logger.Info("object still present", "kind", kind, "key", key.String())
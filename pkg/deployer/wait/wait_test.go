@@ -24,6 +24,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/go-logr/logr/testr"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -132,3 +133,63 @@ func TestForNamespaceDeleted(t *testing.T) {
 		})
 	}
 }
+
+func TestIsDaemonSetRolloutComplete(t *testing.T) {
+	type testCase struct {
+		name       string
+		generation int64
+		status     appsv1.DaemonSetStatus
+		expected   bool
+	}
+
+	testCases := []testCase{
+		{
+			name:       "fresh daemonset",
+			generation: 1,
+			status: appsv1.DaemonSetStatus{
+				ObservedGeneration:     1,
+				DesiredNumberScheduled: 3,
+				UpdatedNumberScheduled: 3,
+				NumberReady:            3,
+			},
+			expected: true,
+		},
+		{
+			name:       "spec change not yet observed",
+			generation: 2,
+			status: appsv1.DaemonSetStatus{
+				ObservedGeneration:     1,
+				DesiredNumberScheduled: 3,
+				UpdatedNumberScheduled: 3,
+				NumberReady:            3,
+			},
+			expected: false,
+		},
+		{
+			name:       "rollout in progress",
+			generation: 2,
+			status: appsv1.DaemonSetStatus{
+				ObservedGeneration:     2,
+				DesiredNumberScheduled: 3,
+				UpdatedNumberScheduled: 1,
+				NumberReady:            3,
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ds := appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation: tc.generation,
+				},
+				Status: tc.status,
+			}
+			got := IsDaemonSetRolloutComplete(&ds)
+			if got != tc.expected {
+				t.Errorf("rollout complete got %v expected %v", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package diff
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+)
+
+type ObjectRef struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+func (ref ObjectRef) String() string {
+	if ref.Namespace == "" {
+		return ref.Kind + "/" + ref.Name
+	}
+	return ref.Kind + "/" + ref.Namespace + "/" + ref.Name
+}
+
+type ObjectDiff struct {
+	Ref  ObjectRef
+	Text string
+}
+
+// Result holds the differences between the rendered objects and the live objects.
+// Changed objects exist on both sides but differ; Missing objects are rendered but
+// not found in the cluster; Unexpected objects are found in the namespaces owned
+// by the deployer but are not rendered.
+type Result struct {
+	Changed    []ObjectDiff
+	Missing    []ObjectRef
+	Unexpected []ObjectRef
+}
+
+func (res Result) IsEmpty() bool {
+	return len(res.Changed) == 0 && len(res.Missing) == 0 && len(res.Unexpected) == 0
+}
+
+func (res Result) Write(w io.Writer) {
+	for _, od := range res.Changed {
+		fmt.Fprint(w, od.Text)
+	}
+	if len(res.Missing) > 0 {
+		fmt.Fprintf(w, "missing objects:\n")
+		for _, ref := range res.Missing {
+			fmt.Fprintf(w, "- %s\n", ref.String())
+		}
+	}
+	if len(res.Unexpected) > 0 {
+		fmt.Fprintf(w, "unexpected objects:\n")
+		for _, ref := range res.Unexpected {
+			fmt.Fprintf(w, "- %s\n", ref.String())
+		}
+	}
+}
+
+// Objects compares the rendered `objs` against their live counterparts.
+// Only the fields set in the rendered objects are compared.
+func Objects(ctx context.Context, cli client.Client, objs []client.Object) (Result, error) {
+	res := Result{}
+	rendered := make(map[ObjectRef]bool)
+	namespaces := []string{}
+	namespacedKinds := []schema.GroupVersionKind{}
+	seenKinds := make(map[schema.GroupVersionKind]bool)
+
+	for _, obj := range objs {
+		gvk, err := apiutil.GVKForObject(obj, cli.Scheme())
+		if err != nil {
+			return res, err
+		}
+		ref := ObjectRef{Kind: gvk.Kind, Namespace: obj.GetNamespace(), Name: obj.GetName()}
+		rendered[ref] = true
+		if gvk.Kind == "Namespace" {
+			namespaces = append(namespaces, obj.GetName())
+		}
+		if obj.GetNamespace() != "" && !seenKinds[gvk] {
+			seenKinds[gvk] = true
+			namespacedKinds = append(namespacedKinds, gvk)
+		}
+
+		desired, err := manifests.NormalizeObject(obj)
+		if err != nil {
+			return res, err
+		}
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(gvk)
+		err = cli.Get(ctx, client.ObjectKeyFromObject(obj), live)
+		if apierrors.IsNotFound(err) {
+			res.Missing = append(res.Missing, ref)
+			continue
+		}
+		if err != nil {
+			return res, err
+		}
+
+		text, err := Unified(ref, desired, live)
+		if err != nil {
+			return res, err
+		}
+		if text != "" {
+			res.Changed = append(res.Changed, ObjectDiff{Ref: ref, Text: text})
+		}
+	}
+
+	for _, ns := range namespaces {
+		for _, gvk := range namespacedKinds {
+			liveList := &unstructured.UnstructuredList{}
+			liveList.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+			err := cli.List(ctx, liveList, client.InNamespace(ns))
+			if err != nil {
+				return res, err
+			}
+			for _, item := range liveList.Items {
+				ref := ObjectRef{Kind: gvk.Kind, Namespace: item.GetNamespace(), Name: item.GetName()}
+				if rendered[ref] || isAutoCreated(gvk.Kind, &item) {
+					continue
+				}
+				res.Unexpected = append(res.Unexpected, ref)
+			}
+		}
+	}
+	sort.Slice(res.Unexpected, func(i, j int) bool {
+		return res.Unexpected[i].String() < res.Unexpected[j].String()
+	})
+	return res, nil
+}
+
+// Unified returns the unified YAML diff between the live object, pruned of the
+// fields not set in the desired object, and the desired object. Returns empty
+// string if the objects match.
+func Unified(ref ObjectRef, desired, live *unstructured.Unstructured) (string, error) {
+	desiredData, err := manifests.SerializeObjectToData(desired)
+	if err != nil {
+		return "", err
+	}
+	pruned := &unstructured.Unstructured{
+		Object: manifests.PruneObject(live.Object, desired.Object),
+	}
+	liveData, err := manifests.SerializeObjectToData(pruned)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(liveData)),
+		B:        difflib.SplitLines(string(desiredData)),
+		FromFile: "live/" + ref.String(),
+		ToFile:   "rendered/" + ref.String(),
+		Context:  3,
+	})
+}
+
+// isAutoCreated tells if the object is created by the cluster itself in
+// every namespace, or is owned by another object, so it is not expected
+// to be rendered.
+func isAutoCreated(kind string, obj *unstructured.Unstructured) bool {
+	if len(obj.GetOwnerReferences()) > 0 {
+		return true
+	}
+	switch kind {
+	case "ServiceAccount":
+		return obj.GetName() == "default" || obj.GetName() == "builder" || obj.GetName() == "deployer"
+	case "ConfigMap":
+		return obj.GetName() == "kube-root-ca.crt" || obj.GetName() == "openshift-service-ca.crt"
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package diff
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestObjects(t *testing.T) {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "tas-test"},
+	}
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tas-test", Name: "rte"},
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tas-test", Name: "rte-config"},
+		Data:       map[string]string{"config.yaml": "foo: new\n"},
+	}
+
+	liveCm := cm.DeepCopy()
+	liveCm.Data["config.yaml"] = "foo: old\n"
+	liveCm.Labels = map[string]string{"added-by": "admin"}
+	liveObjs := []client.Object{
+		ns.DeepCopy(),
+		liveCm,
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tas-test", Name: "leftover"},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tas-test", Name: "kube-root-ca.crt"},
+		},
+	}
+
+	cli := fake.NewClientBuilder().WithObjects(liveObjs...).Build()
+	res, err := Objects(context.TODO(), cli, []client.Object{ns, sa, cm})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedMissing := []ObjectRef{{Kind: "ServiceAccount", Namespace: "tas-test", Name: "rte"}}
+	if !reflect.DeepEqual(res.Missing, expectedMissing) {
+		t.Errorf("missing objects got %v expected %v", res.Missing, expectedMissing)
+	}
+	expectedUnexpected := []ObjectRef{{Kind: "ConfigMap", Namespace: "tas-test", Name: "leftover"}}
+	if !reflect.DeepEqual(res.Unexpected, expectedUnexpected) {
+		t.Errorf("unexpected objects got %v expected %v", res.Unexpected, expectedUnexpected)
+	}
+	if len(res.Changed) != 1 {
+		t.Fatalf("changed objects got %d expected 1: %v", len(res.Changed), res.Changed)
+	}
+	text := res.Changed[0].Text
+	for _, line := range []string{
+		"--- live/ConfigMap/tas-test/rte-config",
+		"+++ rendered/ConfigMap/tas-test/rte-config",
+		"-    foo: old",
+		"+    foo: new",
+	} {
+		if !strings.Contains(text, line) {
+			t.Errorf("missing %q in diff:\n%s", line, text)
+		}
+	}
+	if strings.Contains(text, "added-by") || strings.Contains(text, "resourceVersion") {
+		t.Errorf("diff includes fields not rendered:\n%s", text)
+	}
+}
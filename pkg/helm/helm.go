@@ -0,0 +1,387 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package helm
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/kustomize"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+)
+
+const (
+	ChartAPIVersion = "v2"
+	ChartName       = "topology-aware-scheduling"
+	ChartFileName   = "Chart.yaml"
+	ValuesFileName  = "values.yaml"
+	SchemaFileName  = "values.schema.json"
+	TemplatesDir    = "templates"
+	CRDsDir         = "crds"
+)
+
+// Metadata is the content of Chart.yaml
+type Metadata struct {
+	APIVersion  string `json:"apiVersion"`
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	AppVersion  string `json:"appVersion,omitempty"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type,omitempty"`
+}
+
+// Toggle is a boolean knob which changes the structure of the objects, like enabling
+// the RTE notification file, so it can't be expressed with a plain value. The chart
+// holds the objects rendered with the toggle both on and off, and selects them using
+// a conditional.
+type Toggle struct {
+	// Name identifies the toggle in the states passed to the RenderFunc
+	Name string
+	// Condition is the template expression which tells if the toggle is on
+	Condition string
+	// Value is the path of the boolean value consumed by the condition, if any
+	Value string
+	// Description documents the value in the schema
+	Description string
+	// Default is the state of the toggle in the objects rendered by default
+	Default bool
+}
+
+// RenderFunc renders the components with the given toggles set.
+type RenderFunc func(toggles map[string]bool) ([]kustomize.Component, error)
+
+// Generate returns the files of the chart. All the objects are rendered by default,
+// then again once for each toggle flipped. Each knob found in the objects is replaced
+// by a template expression consuming a value, whose default is taken from the objects
+// rendered by default, so installing the chart without overrides creates the same objects
+// the deployer does. Objects changing with a toggle are wrapped in a conditional;
+// an object can change with at most one toggle.
+func Generate(meta Metadata, toggles []Toggle, render RenderFunc) (kustomize.Tree, error) {
+	ch := newChart()
+	defaults := make(map[string]bool)
+	for _, tg := range toggles {
+		defaults[tg.Name] = tg.Default
+		if tg.Value != "" {
+			ch.addValue(tg.Value, tg.Default, booleanSchema(tg.Description))
+		}
+	}
+
+	base, err := ch.renderVariant(render, defaults)
+	if err != nil {
+		return nil, err
+	}
+	flipped := make([]variant, len(toggles))
+	for idx, tg := range toggles {
+		state := make(map[string]bool)
+		for name, val := range defaults {
+			state[name] = val
+		}
+		state[tg.Name] = !tg.Default
+		flipped[idx], err = ch.renderVariant(render, state)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tree := kustomize.Tree{}
+	for _, key := range mergeKeys(base, flipped) {
+		doc, inBase := base.docs[key]
+		var conditional *Toggle
+		var flippedDoc document
+		var inFlipped bool
+		for idx := range toggles {
+			other, ok := flipped[idx].docs[key]
+			if inBase == ok && other.text == doc.text {
+				continue
+			}
+			if conditional != nil {
+				return nil, fmt.Errorf("%s changes with both %s and %s", key, conditional.Name, toggles[idx].Name)
+			}
+			conditional = &toggles[idx]
+			flippedDoc, inFlipped = other, ok
+		}
+
+		if conditional == nil {
+			if doc.isCRD {
+				tree[path.Join(CRDsDir, doc.component+"-"+doc.file)] = []byte(doc.text)
+			} else {
+				tree[path.Join(TemplatesDir, doc.component, doc.file)] = []byte(doc.text)
+			}
+			continue
+		}
+
+		ref := doc
+		if !inBase {
+			ref = flippedDoc
+		}
+		on, off := flippedDoc.text, doc.text
+		if !inFlipped {
+			on = ""
+		}
+		if !inBase {
+			off = ""
+		}
+		if conditional.Default {
+			on, off = off, on
+		}
+		cond := conditional.Condition
+		if on == "" {
+			cond, on, off = "not ("+cond+")", off, ""
+		}
+		tree[path.Join(TemplatesDir, ref.component, ref.file)] = []byte(wrapConditional(cond, on, off))
+	}
+
+	meta.APIVersion = ChartAPIVersion
+	if meta.Name == "" {
+		meta.Name = ChartName
+	}
+	data, err := yaml.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+	tree[ChartFileName] = data
+
+	data, err = yaml.Marshal(ch.values)
+	if err != nil {
+		return nil, err
+	}
+	tree[ValuesFileName] = data
+
+	data, err = json.MarshalIndent(ch.schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	tree[SchemaFileName] = append(data, '\n')
+	return tree, nil
+}
+
+type valueKind int
+
+const (
+	// inlineValue replaces a scalar, like an image name or a flag
+	inlineValue valueKind = iota
+	// blockValue replaces a mapping, which is omitted if empty
+	blockValue
+)
+
+// placeholder is the template expression replacing a knob in the objects
+type placeholder struct {
+	path string
+	kind valueKind
+	// format is the printf format applied to inline values, if any
+	format string
+	quote  bool
+}
+
+type chart struct {
+	values       map[string]interface{}
+	schema       map[string]interface{}
+	placeholders []placeholder
+}
+
+func newChart() *chart {
+	return &chart{
+		values: make(map[string]interface{}),
+		schema: map[string]interface{}{
+			"$schema":    "https://json-schema.org/draft-07/schema#",
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		},
+	}
+}
+
+const placeholderPrefix = "tas-helm-placeholder-"
+
+var placeholderRE = regexp.MustCompile(placeholderPrefix + `([0-9]+)`)
+
+// inline returns the placeholder of a scalar consuming the value at the given path.
+// The first registration of a path sets the default and the schema of the value.
+func (ch *chart) inline(valuePath string, def interface{}, schema map[string]interface{}, format string, quote bool) string {
+	ch.addValue(valuePath, def, schema)
+	return ch.addPlaceholder(placeholder{path: valuePath, kind: inlineValue, format: format, quote: quote})
+}
+
+// block returns the placeholder of a mapping consuming the value at the given path.
+func (ch *chart) block(valuePath string, def interface{}, schema map[string]interface{}) string {
+	ch.addValue(valuePath, def, schema)
+	return ch.addPlaceholder(placeholder{path: valuePath, kind: blockValue})
+}
+
+func (ch *chart) addPlaceholder(ph placeholder) string {
+	for idx, other := range ch.placeholders {
+		if other == ph {
+			return placeholderPrefix + strconv.Itoa(idx)
+		}
+	}
+	ch.placeholders = append(ch.placeholders, ph)
+	return placeholderPrefix + strconv.Itoa(len(ch.placeholders)-1)
+}
+
+func (ch *chart) addValue(valuePath string, def interface{}, schema map[string]interface{}) {
+	keys := strings.Split(valuePath, ".")
+	values := ch.values
+	props := ch.schema["properties"].(map[string]interface{})
+	for _, key := range keys[:len(keys)-1] {
+		if _, ok := values[key]; !ok {
+			values[key] = make(map[string]interface{})
+			props[key] = map[string]interface{}{
+				"type":                 "object",
+				"additionalProperties": false,
+				"properties":           map[string]interface{}{},
+			}
+		}
+		values = values[key].(map[string]interface{})
+		props = props[key].(map[string]interface{})["properties"].(map[string]interface{})
+	}
+	last := keys[len(keys)-1]
+	if _, ok := values[last]; ok {
+		return
+	}
+	values[last] = def
+	props[last] = schema
+}
+
+// expand returns the template text replacing the line holding the placeholder.
+// `prefix` and `suffix` are the text around the placeholder in that line.
+func (ph placeholder) expand(prefix, suffix string) string {
+	ref := ".Values." + ph.path
+	if ph.kind == blockValue {
+		key := strings.TrimRight(prefix, " ")
+		indent := len(prefix) - len(strings.TrimLeft(prefix, " "))
+		return fmt.Sprintf("{{- with %s }}\n%s{{ toYaml . | nindent %d }}\n{{- end }}", ref, key, indent+2)
+	}
+	expr := ref
+	if ph.format != "" {
+		expr = fmt.Sprintf("printf %q %s", ph.format, ref)
+	}
+	if ph.quote {
+		expr += " | quote"
+	}
+	return prefix + "{{ " + expr + " }}" + suffix
+}
+
+type document struct {
+	component string
+	file      string
+	text      string
+	isCRD     bool
+}
+
+type variant struct {
+	keys []string
+	docs map[string]document
+}
+
+func (ch *chart) renderVariant(render RenderFunc, toggles map[string]bool) (variant, error) {
+	vr := variant{
+		docs: make(map[string]document),
+	}
+	comps, err := render(toggles)
+	if err != nil {
+		return vr, err
+	}
+	for _, comp := range comps {
+		for _, obj := range comp.Objects {
+			kind := obj.GetObjectKind().GroupVersionKind().Kind
+			if kind == "" {
+				return vr, fmt.Errorf("component %s: object %q without kind", comp.Name, obj.GetName())
+			}
+			uobj, err := manifests.NormalizeObject(obj)
+			if err != nil {
+				return vr, err
+			}
+			if err := ch.parametrize(comp.Name, uobj); err != nil {
+				return vr, err
+			}
+			text, err := ch.templateText(uobj)
+			if err != nil {
+				return vr, err
+			}
+			doc := document{
+				component: comp.Name,
+				file:      kustomize.ObjectFileName(kind, obj.GetName()),
+				text:      text,
+				isCRD:     kind == "CustomResourceDefinition",
+			}
+			key := path.Join(doc.component, doc.file)
+			if _, ok := vr.docs[key]; ok {
+				doc.file = kustomize.ObjectFileName(kind, obj.GetNamespace()+"-"+obj.GetName())
+				key = path.Join(doc.component, doc.file)
+			}
+			vr.keys = append(vr.keys, key)
+			vr.docs[key] = doc
+		}
+	}
+	return vr, nil
+}
+
+// templateText serializes the object replacing the placeholders with template expressions.
+func (ch *chart) templateText(obj *unstructured.Unstructured) (string, error) {
+	data, err := manifests.SerializeObjectToData(obj)
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Split(string(data), "\n")
+	for idx, line := range lines {
+		loc := placeholderRE.FindStringSubmatchIndex(line)
+		if loc == nil {
+			continue
+		}
+		id, err := strconv.Atoi(line[loc[2]:loc[3]])
+		if err != nil || id >= len(ch.placeholders) {
+			return "", fmt.Errorf("unknown placeholder in %q", line)
+		}
+		lines[idx] = ch.placeholders[id].expand(line[:loc[0]], line[loc[1]:])
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func wrapConditional(cond, on, off string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "{{- if %s }}\n", cond)
+	sb.WriteString(on)
+	if off != "" {
+		sb.WriteString("{{- else }}\n")
+		sb.WriteString(off)
+	}
+	sb.WriteString("{{- end }}\n")
+	return sb.String()
+}
+
+// mergeKeys returns the keys of the documents of all the variants, in rendering order.
+func mergeKeys(base variant, others []variant) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, vr := range append([]variant{base}, others...) {
+		for _, key := range vr.keys {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,418 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package helm
+
+import (
+	"bytes"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+
+	sprig "github.com/go-task/slim-sprig/v3"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/images"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/kustomize"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+)
+
+const (
+	toggleNotif      = "notif"
+	toggleLeaderElec = "leaderElection"
+)
+
+func testToggles() []Toggle {
+	return []Toggle{
+		{
+			Name:      toggleLeaderElec,
+			Condition: "gt (int .Values.scheduler.replicas) 1",
+		},
+		{
+			Name:        toggleNotif,
+			Condition:   ".Values." + ValueUpdaterNotifEnable,
+			Value:       ValueUpdaterNotifEnable,
+			Description: "notifications",
+		},
+	}
+}
+
+func TestGenerateDefaults(t *testing.T) {
+	tree, err := Generate(Metadata{Version: "1.2.3"}, testToggles(), renderTestObjects)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedPaths := []string{
+		ChartFileName,
+		"crds/api-customresourcedefinition-noderesourcetopologies.topology.node.k8s.io.yaml",
+		"templates/rte/daemonset-resource-topology-exporter.yaml",
+		"templates/sched/configmap-scheduler-config.yaml",
+		"templates/sched/deployment-topology-aware-scheduler.yaml",
+		SchemaFileName,
+		ValuesFileName,
+	}
+	if got := tree.Paths(); !reflect.DeepEqual(got, expectedPaths) {
+		t.Fatalf("paths: got %v expected %v", got, expectedPaths)
+	}
+
+	meta := Metadata{}
+	if err := yaml.Unmarshal(tree[ChartFileName], &meta); err != nil {
+		t.Fatalf("cannot decode chart metadata: %v", err)
+	}
+	if meta.APIVersion != ChartAPIVersion || meta.Name != ChartName || meta.Version != "1.2.3" {
+		t.Errorf("unexpected chart metadata: %+v", meta)
+	}
+
+	comps, err := renderTestObjects(map[string]bool{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := executeTemplates(t, tree, nil)
+	checkObjects(t, comps, got)
+}
+
+func TestGenerateOverrides(t *testing.T) {
+	tree, err := Generate(Metadata{Version: "1.2.3"}, testToggles(), renderTestObjects)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := executeTemplates(t, tree, map[string]interface{}{
+		"imagePullPolicy": "IfNotPresent",
+		"images": map[string]interface{}{
+			"scheduler": "quay.io/custom/scheduler:latest",
+		},
+		"scheduler": map[string]interface{}{
+			"replicas": 3,
+			"verbose":  6,
+			"scoringStrategy": map[string]interface{}{
+				"type": manifests.ScoringStrategyMostAllocated,
+			},
+		},
+		"updater": map[string]interface{}{
+			"notifEnable": true,
+			"syncPeriod":  "1m",
+		},
+	})
+
+	comps, err := renderTestObjects(map[string]bool{toggleNotif: true, toggleLeaderElec: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, comp := range comps {
+		for _, obj := range comp.Objects {
+			switch o := obj.(type) {
+			case *appsv1.Deployment:
+				o.Spec.Replicas = newInt32(3)
+				o.Spec.Template.Spec.Containers[0].Image = "quay.io/custom/scheduler:latest"
+				o.Spec.Template.Spec.Containers[0].ImagePullPolicy = corev1.PullIfNotPresent
+				o.Spec.Template.Spec.Containers[0].Args = []string{"/bin/kube-scheduler", "-v=6"}
+			case *appsv1.DaemonSet:
+				o.Spec.Template.Spec.Containers[0].ImagePullPolicy = corev1.PullIfNotPresent
+				o.Spec.Template.Spec.Containers[0].Args = []string{"--sleep-interval=1m", "-v=1", "--pods-fingerprint=true"}
+			case *corev1.ConfigMap:
+				o.Data[manifests.SchedulerConfigFileName] = testSchedulerConfig(true, "    scoringStrategy:\n      type: MostAllocated\n")
+			}
+		}
+	}
+	checkObjects(t, comps, got)
+}
+
+func TestGenerateConflictingToggles(t *testing.T) {
+	render := func(state map[string]bool) ([]kustomize.Component, error) {
+		cm := &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tas", Name: "cm"},
+			Data: map[string]string{
+				"notif":  boolString(state[toggleNotif]),
+				"leader": boolString(state[toggleLeaderElec]),
+			},
+		}
+		return []kustomize.Component{{Name: "rte", Objects: []client.Object{cm}}}, nil
+	}
+	_, err := Generate(Metadata{}, testToggles(), render)
+	if err == nil {
+		t.Fatalf("expected error for an object changing with two toggles")
+	}
+}
+
+func TestValuesSchema(t *testing.T) {
+	tree, err := Generate(Metadata{}, testToggles(), renderTestObjects)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	schema := make(map[string]interface{})
+	if err := yaml.Unmarshal(tree[SchemaFileName], &schema); err != nil {
+		t.Fatalf("cannot decode schema: %v", err)
+	}
+	values := make(map[string]interface{})
+	if err := yaml.Unmarshal(tree[ValuesFileName], &values); err != nil {
+		t.Fatalf("cannot decode values: %v", err)
+	}
+	// every value must be described by the schema
+	var check func(prefix string, vals, props map[string]interface{})
+	check = func(prefix string, vals, props map[string]interface{}) {
+		for key, val := range vals {
+			prop, ok := props[key].(map[string]interface{})
+			if !ok {
+				t.Errorf("value %s%s missing from the schema", prefix, key)
+				continue
+			}
+			nested, isMap := val.(map[string]interface{})
+			nestedProps, hasProps := prop["properties"].(map[string]interface{})
+			if isMap && hasProps && prop["description"] == nil {
+				check(prefix+key+".", nested, nestedProps)
+			}
+		}
+	}
+	check("", values, schema["properties"].(map[string]interface{}))
+
+	for _, path := range []string{ValueSchedulerReplicas, ValueUpdaterNotifEnable, ValueUpdaterPFPEnable, ValueSchedulerCache} {
+		if _, ok := lookup(values, path); !ok {
+			t.Errorf("missing value %s", path)
+		}
+	}
+}
+
+func renderTestObjects(state map[string]bool) ([]kustomize.Component, error) {
+	imgs := images.Get()
+
+	crd := &apiextensionv1.CustomResourceDefinition{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1", Kind: "CustomResourceDefinition"},
+		ObjectMeta: metav1.ObjectMeta{Name: "noderesourcetopologies.topology.node.k8s.io"},
+	}
+
+	replicas := int32(1)
+	if state[toggleLeaderElec] {
+		replicas = 2
+	}
+	dp := &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tas-scheduler", Name: "topology-aware-scheduler"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:            "topology-aware-scheduler",
+							Image:           imgs.SchedulerPluginScheduler,
+							ImagePullPolicy: corev1.PullAlways,
+							Args:            []string{"/bin/kube-scheduler", "-v=4"},
+						},
+					},
+				},
+			},
+		},
+	}
+	cm := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tas-scheduler", Name: "scheduler-config"},
+		Data: map[string]string{
+			manifests.SchedulerConfigFileName: testSchedulerConfig(state[toggleLeaderElec], ""),
+		},
+	}
+
+	ds := &appsv1.DaemonSet{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DaemonSet"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tas-topology-updater", Name: "resource-topology-exporter"},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:            manifests.ContainerNameRTE,
+							Image:           imgs.ResourceTopologyExporter,
+							ImagePullPolicy: corev1.PullAlways,
+							Args:            []string{"--sleep-interval=10s", "-v=1", "--pods-fingerprint=true"},
+						},
+					},
+				},
+			},
+		},
+	}
+	if state[toggleNotif] {
+		ds.Spec.Template.Spec.Volumes = []corev1.Volume{
+			{
+				Name: "host-run-rte",
+				VolumeSource: corev1.VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{Path: "/run/rte"},
+				},
+			},
+		}
+	}
+
+	return []kustomize.Component{
+		{Name: manifests.ComponentAPI, Objects: []client.Object{crd}},
+		{Name: manifests.ComponentResourceTopologyExporter, Objects: []client.Object{ds}},
+		{Name: manifests.ComponentSchedulerPlugin, Objects: []client.Object{cm, dp}},
+	}, nil
+}
+
+func testSchedulerConfig(leaderElect bool, scoringStrategy string) string {
+	lead := "  leaderElect: false\n"
+	if leaderElect {
+		lead = "  leaderElect: true\n  resourceName: nrtmatch-scheduler\n  resourceNamespace: tas-scheduler\n"
+	}
+	return "apiVersion: kubescheduler.config.k8s.io/v1beta3\n" +
+		"kind: KubeSchedulerConfiguration\n" +
+		"leaderElection:\n" + lead +
+		"profiles:\n" +
+		"- pluginConfig:\n" +
+		"  - args:\n" +
+		"      cache:\n" +
+		"        foreignPodsDetect: OnlyExclusiveResources\n" +
+		"        resyncMethod: Autodetect\n" +
+		"      cacheResyncPeriodSeconds: 5\n" +
+		strings.ReplaceAll(scoringStrategy, "    ", "      ") +
+		"    name: NodeResourceTopologyMatch\n" +
+		"  schedulerName: topology-aware-scheduler\n"
+}
+
+// executeTemplates renders the templates like helm does, using the values
+// of the chart merged with the given overrides.
+func executeTemplates(t *testing.T, tree kustomize.Tree, overrides map[string]interface{}) map[string]map[string]interface{} {
+	t.Helper()
+	values := make(map[string]interface{})
+	if err := yaml.Unmarshal(tree[ValuesFileName], &values); err != nil {
+		t.Fatalf("cannot decode values: %v", err)
+	}
+	mergeValues(values, overrides)
+
+	funcs := sprig.TxtFuncMap()
+	funcs["toYaml"] = func(v interface{}) string {
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSuffix(string(data), "\n")
+	}
+
+	ret := make(map[string]map[string]interface{})
+	for _, p := range tree.Paths() {
+		dir := strings.Split(p, "/")[0]
+		if dir != TemplatesDir && dir != CRDsDir {
+			continue
+		}
+		tmpl, err := template.New(p).Funcs(funcs).Parse(string(tree[p]))
+		if err != nil {
+			t.Fatalf("cannot parse %s: %v", p, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, map[string]interface{}{"Values": values}); err != nil {
+			t.Fatalf("cannot execute %s: %v", p, err)
+		}
+		obj := make(map[string]interface{})
+		if err := yaml.Unmarshal(buf.Bytes(), &obj); err != nil {
+			t.Fatalf("cannot decode %s: %v\n%s", p, err, buf.String())
+		}
+		ret[path.Base(p)] = obj
+	}
+	return ret
+}
+
+func checkObjects(t *testing.T, comps []kustomize.Component, got map[string]map[string]interface{}) {
+	t.Helper()
+	for _, comp := range comps {
+		for _, obj := range comp.Objects {
+			expected, err := manifests.NormalizeObject(obj)
+			if err != nil {
+				t.Fatalf("cannot normalize object: %v", err)
+			}
+			name := kustomize.ObjectFileName(expected.GetKind(), obj.GetName())
+			if comp.Name == manifests.ComponentAPI {
+				name = comp.Name + "-" + name
+			}
+			gotObj, ok := got[name]
+			if !ok {
+				t.Errorf("missing object %s", name)
+				continue
+			}
+			if cm, ok := obj.(*corev1.ConfigMap); ok {
+				// the configuration is re-encoded, so compare it decoded
+				checkConfigData(t, cm, gotObj)
+				continue
+			}
+			if !reflect.DeepEqual(expected.Object, gotObj) {
+				t.Errorf("object %s mismatch:\nexpected %v\ngot      %v", name, expected.Object, gotObj)
+			}
+		}
+	}
+}
+
+func checkConfigData(t *testing.T, cm *corev1.ConfigMap, gotObj map[string]interface{}) {
+	t.Helper()
+	gotData, _ := gotObj["data"].(map[string]interface{})
+	gotText, _ := gotData[manifests.SchedulerConfigFileName].(string)
+	var expected, got map[string]interface{}
+	if err := yaml.Unmarshal([]byte(cm.Data[manifests.SchedulerConfigFileName]), &expected); err != nil {
+		t.Fatalf("cannot decode expected config: %v", err)
+	}
+	if err := yaml.Unmarshal([]byte(gotText), &got); err != nil {
+		t.Fatalf("cannot decode rendered config: %v\n%s", err, gotText)
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("config mismatch:\nexpected %v\ngot      %v", expected, got)
+	}
+}
+
+func mergeValues(dst, src map[string]interface{}) {
+	for key, val := range src {
+		srcMap, srcIsMap := val.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeValues(dstMap, srcMap)
+			continue
+		}
+		dst[key] = val
+	}
+}
+
+func lookup(values map[string]interface{}, path string) (interface{}, bool) {
+	var cur interface{} = values
+	for _, key := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		cur, ok = m[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+func boolString(val bool) string {
+	if val {
+		return "true"
+	}
+	return "false"
+}
+
+func newInt32(val int32) *int32 {
+	return &val
+}
@@ -0,0 +1,389 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package helm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/images"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+)
+
+const (
+	ValueImagePullPolicy                   = "imagePullPolicy"
+	ValueSchedulerReplicas                 = "scheduler.replicas"
+	ValueSchedulerVerbose                  = "scheduler.verbose"
+	ValueSchedulerCacheResyncPeriodSeconds = "scheduler.cacheResyncPeriodSeconds"
+	ValueSchedulerCache                    = "scheduler.cache"
+	ValueSchedulerScoringStrategy          = "scheduler.scoringStrategy"
+	ValueSchedulerLeaderElectResourceName  = "scheduler.leaderElection.resourceName"
+	ValueSchedulerLeaderElectResourceNs    = "scheduler.leaderElection.resourceNamespace"
+	ValueUpdaterVerbose                    = "updater.verbose"
+	ValueUpdaterSyncPeriod                 = "updater.syncPeriod"
+	ValueUpdaterPFPEnable                  = "updater.pfpEnable"
+	ValueUpdaterNotifEnable                = "updater.notifEnable"
+)
+
+type imageParam struct {
+	path        string
+	image       string
+	description string
+	flags       []flagParam
+}
+
+type flagParam struct {
+	flag        string
+	path        string
+	description string
+	schema      func(desc string) map[string]interface{}
+	convert     func(val string) (interface{}, error)
+}
+
+func updaterFlags() []flagParam {
+	return []flagParam{
+		{flag: "-v", path: ValueUpdaterVerbose, description: "verbosity of the topology updater", schema: verboseSchema, convert: toInt},
+		{flag: "--sleep-interval", path: ValueUpdaterSyncPeriod, description: "interval between the updates of the NodeResourceTopology objects", schema: durationSchema, convert: toString},
+		{flag: "--pods-fingerprint", path: ValueUpdaterPFPEnable, description: "publish the fingerprint of the pods running on the node", schema: booleanSchema, convert: toBool},
+	}
+}
+
+func imageParams() []imageParam {
+	imgs := images.Get()
+	return []imageParam{
+		{
+			path:        "images.scheduler",
+			image:       imgs.SchedulerPluginScheduler,
+			description: "image of the scheduler plugin",
+			flags: []flagParam{
+				{flag: "-v", path: ValueSchedulerVerbose, description: "verbosity of the scheduler plugin", schema: verboseSchema, convert: toInt},
+			},
+		},
+		{
+			path:        "images.controller",
+			image:       imgs.SchedulerPluginController,
+			description: "image of the scheduler plugin controller",
+		},
+		{
+			path:        "images.resourceTopologyExporter",
+			image:       imgs.ResourceTopologyExporter,
+			description: "image of the resource-topology-exporter topology updater",
+			flags:       updaterFlags(),
+		},
+		{
+			path:        "images.nodeFeatureDiscovery",
+			image:       imgs.NodeFeatureDiscovery,
+			description: "image of the node-feature-discovery topology updater",
+			flags:       updaterFlags(),
+		},
+	}
+}
+
+// parametrize replaces the knobs of the object with placeholders.
+func (ch *chart) parametrize(component string, obj *unstructured.Unstructured) error {
+	switch obj.GetKind() {
+	case "Deployment", "DaemonSet":
+		return ch.parametrizeWorkload(component, obj)
+	case "ConfigMap":
+		if component == manifests.ComponentSchedulerPlugin {
+			return ch.parametrizeSchedulerConfig(obj)
+		}
+	case "Role":
+		if component == manifests.ComponentSchedulerPlugin {
+			return ch.parametrizeLeaderElectionRole(obj)
+		}
+	}
+	return nil
+}
+
+func (ch *chart) parametrizeWorkload(component string, obj *unstructured.Unstructured) error {
+	if obj.GetKind() == "Deployment" && component == manifests.ComponentSchedulerPlugin {
+		if val, ok, _ := unstructured.NestedFieldNoCopy(obj.Object, "spec", "replicas"); ok {
+			replicas, ok := toInt64(val)
+			if !ok {
+				return fmt.Errorf("%s: unexpected replicas %v", obj.GetName(), val)
+			}
+			ph := ch.inline(ValueSchedulerReplicas, replicas, integerSchema("replicas of the scheduler plugin deployments. Leader election is enabled with more than 1 replica", 1), "", false)
+			if err := unstructured.SetNestedField(obj.Object, ph, "spec", "replicas"); err != nil {
+				return err
+			}
+		}
+	}
+
+	containers, ok, err := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+	if !ok || err != nil {
+		return err
+	}
+	for _, item := range containers {
+		cnt, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		image, _, _ := unstructured.NestedString(cnt, "image")
+		imgParam, ok := findImageParam(image)
+		if !ok {
+			continue
+		}
+		cnt["image"] = ch.inline(imgParam.path, image, stringSchema(imgParam.description), "", true)
+		if policy, ok, _ := unstructured.NestedString(cnt, "imagePullPolicy"); ok {
+			cnt["imagePullPolicy"] = ch.inline(ValueImagePullPolicy, policy, enumSchema("pull policy of the images", "Always", "IfNotPresent", "Never"), "", true)
+		}
+
+		args, _, _ := unstructured.NestedStringSlice(cnt, "args")
+		if len(args) == 0 {
+			continue
+		}
+		newArgs := make([]interface{}, 0, len(args))
+		for _, arg := range args {
+			newArg, err := ch.parametrizeArg(imgParam.flags, arg)
+			if err != nil {
+				return fmt.Errorf("%s: %w", obj.GetName(), err)
+			}
+			newArgs = append(newArgs, newArg)
+		}
+		cnt["args"] = newArgs
+	}
+	return unstructured.SetNestedSlice(obj.Object, containers, "spec", "template", "spec", "containers")
+}
+
+func (ch *chart) parametrizeArg(flags []flagParam, arg string) (string, error) {
+	name, val, found := strings.Cut(arg, "=")
+	if !found {
+		return arg, nil
+	}
+	for _, fp := range flags {
+		if fp.flag != name {
+			continue
+		}
+		def, err := fp.convert(val)
+		if err != nil {
+			return arg, fmt.Errorf("flag %s: %w", name, err)
+		}
+		return ch.inline(fp.path, def, fp.schema(fp.description), name+"=%v", true), nil
+	}
+	return arg, nil
+}
+
+func (ch *chart) parametrizeSchedulerConfig(obj *unstructured.Unstructured) error {
+	data, ok, err := unstructured.NestedString(obj.Object, "data", manifests.SchedulerConfigFileName)
+	if !ok || err != nil {
+		return err
+	}
+	cfg := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(data), &cfg); err != nil {
+		return fmt.Errorf("cannot decode scheduler config: %w", err)
+	}
+
+	if lead, ok := cfg["leaderElection"].(map[string]interface{}); ok {
+		if name, ok := lead["resourceName"].(string); ok && name != "" {
+			lead["resourceName"] = ch.inline(ValueSchedulerLeaderElectResourceName, name, stringSchema("name of the leader election resource"), "", true)
+		}
+		if ns, ok := lead["resourceNamespace"].(string); ok && ns != "" {
+			lead["resourceNamespace"] = ch.inline(ValueSchedulerLeaderElectResourceNs, ns, stringSchema("namespace of the leader election resource"), "", true)
+		}
+	}
+
+	// only the first profile is parametrized: the additional profiles, if any, are rendered as they are
+	profiles, _ := cfg["profiles"].([]interface{})
+	for _, prof := range profiles[:min(len(profiles), 1)] {
+		profile, ok := prof.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pluginConfigs, _ := profile["pluginConfig"].([]interface{})
+		for _, plConf := range pluginConfigs {
+			pluginConf, ok := plConf.(map[string]interface{})
+			if !ok || pluginConf["name"] != manifests.SchedulerPluginName {
+				continue
+			}
+			args, ok := pluginConf["args"].(map[string]interface{})
+			if !ok {
+				args = make(map[string]interface{})
+				pluginConf["args"] = args
+			}
+			if val, ok := args["cacheResyncPeriodSeconds"]; ok {
+				period, ok := toInt64(val)
+				if !ok {
+					return fmt.Errorf("unexpected cacheResyncPeriodSeconds %v", val)
+				}
+				args["cacheResyncPeriodSeconds"] = ch.inline(ValueSchedulerCacheResyncPeriodSeconds, period, integerSchema("resync period of the scheduler cache, in seconds", 0), "", false)
+			}
+			args["cache"] = ch.block(ValueSchedulerCache, mapOrEmpty(args["cache"]), cacheSchema())
+			args["scoringStrategy"] = ch.block(ValueSchedulerScoringStrategy, mapOrEmpty(args["scoringStrategy"]), scoringStrategySchema())
+		}
+	}
+
+	newData, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	return unstructured.SetNestedField(obj.Object, string(newData), "data", manifests.SchedulerConfigFileName)
+}
+
+// parametrizeLeaderElectionRole handles the role granting access to the leader election
+// resource, whose name is set only if leader election is enabled.
+func (ch *chart) parametrizeLeaderElectionRole(obj *unstructured.Unstructured) error {
+	rules, ok, err := unstructured.NestedSlice(obj.Object, "rules")
+	if !ok || err != nil {
+		return err
+	}
+	for _, item := range rules {
+		rule, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		names, _, _ := unstructured.NestedStringSlice(rule, "resourceNames")
+		if len(names) != 1 || names[0] == "" {
+			continue
+		}
+		rule["resourceNames"] = []interface{}{
+			ch.inline(ValueSchedulerLeaderElectResourceName, names[0], stringSchema("name of the leader election resource"), "", true),
+		}
+	}
+	return unstructured.SetNestedSlice(obj.Object, rules, "rules")
+}
+
+func findImageParam(image string) (imageParam, bool) {
+	if image == "" {
+		return imageParam{}, false
+	}
+	for _, ip := range imageParams() {
+		if ip.image == image {
+			return ip, true
+		}
+	}
+	return imageParam{}, false
+}
+
+func mapOrEmpty(val interface{}) map[string]interface{} {
+	if m, ok := val.(map[string]interface{}); ok {
+		return m
+	}
+	return map[string]interface{}{}
+}
+
+func toInt64(val interface{}) (int64, bool) {
+	switch v := val.(type) {
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	case float64:
+		return int64(v), float64(int64(v)) == v
+	}
+	return 0, false
+}
+
+func toInt(val string) (interface{}, error) {
+	return strconv.Atoi(val)
+}
+
+func toBool(val string) (interface{}, error) {
+	return strconv.ParseBool(val)
+}
+
+func toString(val string) (interface{}, error) {
+	return val, nil
+}
+
+func stringSchema(desc string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": desc,
+	}
+}
+
+func booleanSchema(desc string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "boolean",
+		"description": desc,
+	}
+}
+
+func integerSchema(desc string, minimum int) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "integer",
+		"description": desc,
+		"minimum":     minimum,
+	}
+}
+
+func verboseSchema(desc string) map[string]interface{} {
+	return integerSchema(desc, 0)
+}
+
+func durationSchema(desc string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": desc + ", like 10s or 1m30s",
+		"pattern":     `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`,
+	}
+}
+
+func enumSchema(desc string, values ...string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": desc,
+		"enum":        values,
+	}
+}
+
+func cacheSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":                 "object",
+		"description":          "cache parameters of the scheduler plugin, like the content of --sched-cache-params-config-file",
+		"additionalProperties": false,
+		"properties": map[string]interface{}{
+			"resyncMethod": enumSchema("resync method of the cache",
+				manifests.CacheResyncAutodetect, manifests.CacheResyncAll, manifests.CacheResyncOnlyExclusiveResources),
+			"foreignPodsDetect": enumSchema("detection mode of the pods not scheduled by the scheduler plugin",
+				manifests.ForeignPodsDetectNone, manifests.ForeignPodsDetectAll, manifests.ForeignPodsDetectOnlyExclusiveResources),
+			"informerMode": enumSchema("informer mode of the cache",
+				manifests.CacheInformerShared, manifests.CacheInformerDedicated),
+		},
+	}
+}
+
+func scoringStrategySchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":                 "object",
+		"description":          "scoring strategy of the scheduler plugin, like the content of --sched-scoring-strat-config-file",
+		"additionalProperties": false,
+		"properties": map[string]interface{}{
+			"type": enumSchema("type of the scoring strategy",
+				manifests.ScoringStrategyMostAllocated, manifests.ScoringStrategyBalancedAllocation, manifests.ScoringStrategyLeastAllocated),
+			"resources": map[string]interface{}{
+				"type":        "array",
+				"description": "resources considered by the scoring strategy, with their weights",
+				"items": map[string]interface{}{
+					"type":                 "object",
+					"additionalProperties": false,
+					"required":             []string{"name"},
+					"properties": map[string]interface{}{
+						"name":   stringSchema("name of the resource"),
+						"weight": integerSchema("weight of the resource", 0),
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,241 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+)
+
+const (
+	ComponentInventory = "inventory"
+	DefaultNamespace   = "tas-deployer"
+	DataKey            = "inventory.json"
+)
+
+// Version is the version of the inventory format. Inventories of any other version are rejected.
+const Version = "v1"
+
+// Object identifies an object created by the deployer
+type Object struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+func (obj Object) String() string {
+	if obj.Namespace == "" {
+		return obj.Kind + "/" + obj.Name
+	}
+	return obj.Kind + "/" + obj.Namespace + "/" + obj.Name
+}
+
+// Component records the objects installed for a component of the stack
+type Component struct {
+	Name    string   `json:"name"`
+	Objects []Object `json:"objects"`
+}
+
+// Inventory records what the deployer installed. What to install is always decided by the
+// current options, so they are not recorded.
+type Inventory struct {
+	Version         string      `json:"version"`
+	InstallID       string      `json:"installID"`
+	DeployerVersion string      `json:"deployerVersion"`
+	Components      []Component `json:"components"`
+}
+
+func New(installID string) *Inventory {
+	if installID == "" {
+		installID = manifests.DefaultInstallID
+	}
+	return &Inventory{
+		Version:   Version,
+		InstallID: installID,
+	}
+}
+
+// Name returns the name of the object holding the inventory of the installation `installID`.
+func Name(installID string) string {
+	if installID == "" {
+		installID = manifests.DefaultInstallID
+	}
+	return "tas-inventory-" + installID
+}
+
+// SetComponent records the objects of the component `name`, replacing any previous record.
+func (inv *Inventory) SetComponent(name string, objs []Object) {
+	inv.RemoveComponent(name)
+	inv.Components = append(inv.Components, Component{
+		Name:    name,
+		Objects: objs,
+	})
+	sort.Slice(inv.Components, func(i, j int) bool {
+		return inv.Components[i].Name < inv.Components[j].Name
+	})
+}
+
+// RemoveComponent drops the record of the component `name`, if any.
+func (inv *Inventory) RemoveComponent(name string) {
+	comps := inv.Components[:0]
+	for _, comp := range inv.Components {
+		if comp.Name != name {
+			comps = append(comps, comp)
+		}
+	}
+	inv.Components = comps
+}
+
+// RemoveObjects drops the records of the given objects, and the components left without objects.
+func (inv *Inventory) RemoveObjects(objs []Object) {
+	removed := make(map[string]bool)
+	for _, obj := range objs {
+		removed[obj.String()] = true
+	}
+	comps := inv.Components[:0]
+	for _, comp := range inv.Components {
+		compObjs := []Object{}
+		for _, obj := range comp.Objects {
+			if !removed[obj.String()] {
+				compObjs = append(compObjs, obj)
+			}
+		}
+		if len(compObjs) == 0 {
+			continue
+		}
+		comp.Objects = compObjs
+		comps = append(comps, comp)
+	}
+	inv.Components = comps
+}
+
+// Component returns the record of the component `name`, if any.
+func (inv *Inventory) Component(name string) (Component, bool) {
+	for _, comp := range inv.Components {
+		if comp.Name == name {
+			return comp, true
+		}
+	}
+	return Component{}, false
+}
+
+func (inv *Inventory) IsEmpty() bool {
+	return len(inv.Components) == 0
+}
+
+// ObjectsFrom returns the inventory records of the given objects
+func ObjectsFrom(scheme *runtime.Scheme, objs []client.Object) ([]Object, error) {
+	ret := make([]Object, 0, len(objs))
+	for _, obj := range objs {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return nil, err
+		}
+		apiVersion, kind := gvk.ToAPIVersionAndKind()
+		ret = append(ret, Object{
+			APIVersion: apiVersion,
+			Kind:       kind,
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+		})
+	}
+	return ret, nil
+}
+
+// ToObjects returns the objects recorded for the component, holding only their identity.
+// The object types must be known to `scheme`.
+func (comp Component) ToObjects(scheme *runtime.Scheme) ([]client.Object, error) {
+	ret := make([]client.Object, 0, len(comp.Objects))
+	for _, ref := range comp.Objects {
+		gvk := schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind)
+		rtObj, err := scheme.New(gvk)
+		if err != nil {
+			return nil, err
+		}
+		obj, ok := rtObj.(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("unsupported object %s", ref.String())
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+		obj.SetNamespace(ref.Namespace)
+		obj.SetName(ref.Name)
+		ret = append(ret, obj)
+	}
+	return ret, nil
+}
+
+// ToConfigMap returns the ConfigMap holding the inventory in the given namespace.
+func (inv *Inventory) ToConfigMap(namespace string) (*corev1.ConfigMap, error) {
+	data, err := json.MarshalIndent(inv, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      Name(inv.InstallID),
+			Namespace: namespace,
+		},
+		Data: map[string]string{
+			DataKey: string(data),
+		},
+	}
+	manifests.StampObject(cm, ComponentInventory, inv.InstallID)
+	return cm, nil
+}
+
+func FromConfigMap(cm *corev1.ConfigMap) (*Inventory, error) {
+	data, ok := cm.Data[DataKey]
+	if !ok {
+		return nil, fmt.Errorf("inventory %s/%s: missing key %q", cm.Namespace, cm.Name, DataKey)
+	}
+	inv := Inventory{}
+	if err := json.Unmarshal([]byte(data), &inv); err != nil {
+		return nil, fmt.Errorf("inventory %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+	if inv.Version != Version {
+		return nil, fmt.Errorf("inventory %s/%s: unsupported version %q (supported: %q)", cm.Namespace, cm.Name, inv.Version, Version)
+	}
+	return &inv, nil
+}
+
+// Load reads the inventory of the installation `installID` from the cluster.
+// Returns a NotFound error if the inventory does not exist.
+func Load(ctx context.Context, cli client.Client, namespace, installID string) (*Inventory, error) {
+	cm := corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: namespace, Name: Name(installID)}
+	if err := cli.Get(ctx, key, &cm); err != nil {
+		return nil, err
+	}
+	return FromConfigMap(&cm)
+}
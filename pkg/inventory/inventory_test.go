@@ -0,0 +1,234 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package inventory
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+)
+
+func TestSetComponent(t *testing.T) {
+	type testCase struct {
+		name     string
+		initial  []Component
+		set      Component
+		expected []Component
+	}
+
+	nsObj := Object{APIVersion: "v1", Kind: "Namespace", Name: "tas-topology-updater"}
+	dsObj := Object{APIVersion: "apps/v1", Kind: "DaemonSet", Namespace: "tas-topology-updater", Name: "resource-topology-exporter"}
+	crdObj := Object{APIVersion: "apiextensions.k8s.io/v1", Kind: "CustomResourceDefinition", Name: "noderesourcetopologies.topology.node.k8s.io"}
+
+	testCases := []testCase{
+		{
+			name: "empty",
+			set:  Component{Name: "rte", Objects: []Object{nsObj}},
+			expected: []Component{
+				{Name: "rte", Objects: []Object{nsObj}},
+			},
+		},
+		{
+			name: "replace",
+			initial: []Component{
+				{Name: "rte", Objects: []Object{nsObj}},
+			},
+			set: Component{Name: "rte", Objects: []Object{nsObj, dsObj}},
+			expected: []Component{
+				{Name: "rte", Objects: []Object{nsObj, dsObj}},
+			},
+		},
+		{
+			name: "sorted by name",
+			initial: []Component{
+				{Name: "rte", Objects: []Object{nsObj}},
+			},
+			set: Component{Name: "api", Objects: []Object{crdObj}},
+			expected: []Component{
+				{Name: "api", Objects: []Object{crdObj}},
+				{Name: "rte", Objects: []Object{nsObj}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			inv := New("")
+			inv.Components = tc.initial
+			inv.SetComponent(tc.set.Name, tc.set.Objects)
+			if !reflect.DeepEqual(inv.Components, tc.expected) {
+				t.Errorf("got components %v expected %v", inv.Components, tc.expected)
+			}
+		})
+	}
+}
+
+func TestRemoveObjects(t *testing.T) {
+	nsObj := Object{APIVersion: "v1", Kind: "Namespace", Name: "tas-topology-updater"}
+	dsObj := Object{APIVersion: "apps/v1", Kind: "DaemonSet", Namespace: "tas-topology-updater", Name: "resource-topology-exporter"}
+	crdObj := Object{APIVersion: "apiextensions.k8s.io/v1", Kind: "CustomResourceDefinition", Name: "noderesourcetopologies.topology.node.k8s.io"}
+
+	inv := New("")
+	inv.SetComponent("api", []Object{crdObj})
+	inv.SetComponent("rte", []Object{nsObj, dsObj})
+
+	inv.RemoveObjects([]Object{dsObj, crdObj})
+	expected := []Component{
+		{Name: "rte", Objects: []Object{nsObj}},
+	}
+	if !reflect.DeepEqual(inv.Components, expected) {
+		t.Errorf("got components %v expected %v", inv.Components, expected)
+	}
+
+	inv.RemoveObjects([]Object{nsObj})
+	if !inv.IsEmpty() {
+		t.Errorf("expected empty inventory, got %v", inv.Components)
+	}
+}
+
+func TestObjectsFrom(t *testing.T) {
+	objs := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tas-topology-updater"}},
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Namespace: "tas-topology-updater", Name: "resource-topology-exporter"}},
+	}
+	got, err := ObjectsFrom(scheme.Scheme, objs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Object{
+		{APIVersion: "v1", Kind: "Namespace", Name: "tas-topology-updater"},
+		{APIVersion: "apps/v1", Kind: "DaemonSet", Namespace: "tas-topology-updater", Name: "resource-topology-exporter"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got objects %v expected %v", got, expected)
+	}
+}
+
+func TestComponentToObjects(t *testing.T) {
+	comp := Component{
+		Name: "rte",
+		Objects: []Object{
+			{APIVersion: "v1", Kind: "Namespace", Name: "tas-topology-updater"},
+			{APIVersion: "apps/v1", Kind: "DaemonSet", Namespace: "tas-topology-updater", Name: "resource-topology-exporter"},
+		},
+	}
+	objs, err := comp.ToObjects(scheme.Scheme)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ds, ok := objs[1].(*appsv1.DaemonSet)
+	if !ok {
+		t.Fatalf("unexpected object type %T", objs[1])
+	}
+	if ds.Namespace != "tas-topology-updater" || ds.Name != "resource-topology-exporter" {
+		t.Errorf("unexpected object identity %s/%s", ds.Namespace, ds.Name)
+	}
+
+	_, err = Component{Objects: []Object{{APIVersion: "foo/v1", Kind: "Bar", Name: "baz"}}}.ToObjects(scheme.Scheme)
+	if err == nil {
+		t.Errorf("expected error for unknown kind")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	inv := New("tas1")
+	inv.DeployerVersion = "v0.21.0"
+	inv.SetComponent("rte", []Object{{APIVersion: "v1", Kind: "Namespace", Name: "tas-topology-updater"}})
+
+	cm, err := inv.ToConfigMap(DefaultNamespace)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cm.Name != "tas-inventory-tas1" {
+		t.Errorf("unexpected inventory name %q", cm.Name)
+	}
+	if cm.Labels[manifests.LabelAppInstance] != "tas1" || cm.Labels[manifests.LabelAppComponent] != ComponentInventory {
+		t.Errorf("unexpected inventory labels %v", cm.Labels)
+	}
+
+	cli := fake.NewClientBuilder().WithObjects(cm).Build()
+	got, err := Load(context.Background(), cli, DefaultNamespace, "tas1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, inv) {
+		t.Errorf("got inventory %+v expected %+v", got, inv)
+	}
+
+	_, err = Load(context.Background(), cli, DefaultNamespace, "tas2")
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected NotFound error, got %v", err)
+	}
+}
+
+func TestFromConfigMapVersion(t *testing.T) {
+	type testCase struct {
+		name        string
+		data        string
+		expectedErr bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "supported version",
+			data: `{"version":"v1","installID":"tas1","components":[]}`,
+		},
+		{
+			name:        "unknown version",
+			data:        `{"version":"v2","installID":"tas1","components":[]}`,
+			expectedErr: true,
+		},
+		{
+			name:        "missing version",
+			data:        `{"installID":"tas1","components":[]}`,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: DefaultNamespace, Name: Name("tas1")},
+				Data:       map[string]string{DataKey: tc.data},
+			}
+			inv, err := FromConfigMap(cm)
+			if tc.expectedErr {
+				if err == nil {
+					t.Errorf("expected error, got inventory %+v", inv)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if inv.Version != Version || inv.InstallID != "tas1" {
+				t.Errorf("unexpected inventory %+v", inv)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package kubeletconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+)
+
+const (
+	DefaultConcurrency = 16
+	DefaultNodeTimeout = 10 * time.Second
+)
+
+// ErrMalformedConfigz is reported when the configz of a node can be fetched, but not decoded.
+var ErrMalformedConfigz = errors.New("malformed configz")
+
+type CollectOptions struct {
+	// Concurrency is the maximum number of nodes queried at the same time. Zero means DefaultConcurrency.
+	Concurrency int
+	// NodeTimeout bounds the time to fetch the configuration of each node. Zero means DefaultNodeTimeout.
+	NodeTimeout time.Duration
+}
+
+// Collection holds the kubelet configuration of the nodes, and the errors of the nodes whose configuration
+// can't be fetched. Each node is either in Configs or in Errors.
+type Collection struct {
+	Configs map[string]*kubeletconfigv1beta1.KubeletConfiguration
+	Errors  map[string]error
+}
+
+type fetchFunc func(ctx context.Context, nodeName string) (*kubeletconfigv1beta1.KubeletConfiguration, error)
+
+// CollectFromAPIServer fetches the kubelet configuration of the given nodes through the nodes/proxy
+// subresource of the apiserver. If the apiserver refuses the requests, which affects all the nodes,
+// returns error.
+func CollectFromAPIServer(ctx context.Context, cs kubernetes.Interface, nodeNames []string, opts CollectOptions) (Collection, error) {
+	coll := collect(ctx, nodeNames, opts, func(ctx context.Context, nodeName string) (*kubeletconfigv1beta1.KubeletConfiguration, error) {
+		return GetKubeletConfigForNodeFromAPIServer(ctx, cs, nodeName)
+	})
+	for _, err := range coll.Errors {
+		if apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err) {
+			return coll, err
+		}
+	}
+	return coll, nil
+}
+
+// CollectFromKubectl fetches the kubelet configuration of the given nodes spawning `kubectl proxy`.
+func CollectFromKubectl(ctx context.Context, kc *Kubectl, nodeNames []string, opts CollectOptions, logger logr.Logger) (Collection, error) {
+	cmd := kc.Command("proxy", "-p", "0")
+	stdout, stderr, err := StartWithStreamOutput(cmd)
+	if err != nil {
+		return Collection{}, err
+	}
+	defer stdout.Close()
+	defer stderr.Close()
+	defer func() {
+		// the collection is complete by now: failing to stop the proxy must not turn it into an error
+		if killErr := cmd.Process.Kill(); killErr != nil {
+			logger.Info("failed to stop the kubectl proxy", "error", killErr)
+		}
+	}()
+
+	port, err := FindProxyPort(stdout)
+	if err != nil {
+		return Collection{}, err
+	}
+	logger.Info("using proxy", "port", port)
+
+	tr := &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}
+	client := &http.Client{Transport: tr}
+
+	coll := collect(ctx, nodeNames, opts, func(ctx context.Context, nodeName string) (*kubeletconfigv1beta1.KubeletConfiguration, error) {
+		endpoint := fmt.Sprintf("http://127.0.0.1:%d/api/v1/nodes/%s/proxy/configz", port, nodeName)
+		logger.V(2).Info("requesting to proxy", "endpoint", endpoint)
+		return getKubeletConfigFromEndpoint(ctx, client, endpoint)
+	})
+	return coll, nil
+}
+
+// collect runs fetch for all the nodes, at most opts.Concurrency at the same time.
+func collect(ctx context.Context, nodeNames []string, opts CollectOptions, fetch fetchFunc) Collection {
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = DefaultConcurrency
+	}
+	nodeTimeout := opts.NodeTimeout
+	if nodeTimeout <= 0 {
+		nodeTimeout = DefaultNodeTimeout
+	}
+
+	type result struct {
+		nodeName string
+		conf     *kubeletconfigv1beta1.KubeletConfiguration
+		err      error
+	}
+	results := make(chan result)
+	coll := Collection{
+		Configs: make(map[string]*kubeletconfigv1beta1.KubeletConfiguration),
+		Errors:  make(map[string]error),
+	}
+	next, running := 0, 0
+	for next < len(nodeNames) || running > 0 {
+		for running < concurrency && next < len(nodeNames) {
+			nodeName := nodeNames[next]
+			next++
+			running++
+			go func(nodeName string) {
+				nodeCtx, cancel := context.WithTimeout(ctx, nodeTimeout)
+				defer cancel()
+				conf, err := fetch(nodeCtx, nodeName)
+				results <- result{nodeName: nodeName, conf: conf, err: err}
+			}(nodeName)
+		}
+
+		res := <-results
+		running--
+		if res.err != nil {
+			coll.Errors[res.nodeName] = res.err
+			continue
+		}
+		coll.Configs[res.nodeName] = res.conf
+	}
+	return coll
+}
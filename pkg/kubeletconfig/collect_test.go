@@ -0,0 +1,99 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package kubeletconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+)
+
+func TestCollect(t *testing.T) {
+	var nodeNames []string
+	for idx := 0; idx < 20; idx++ {
+		nodeNames = append(nodeNames, fmt.Sprintf("worker-%d", idx))
+	}
+	errUnreachable := errors.New("unreachable")
+
+	var running, maxRunning atomic.Int32
+	coll := collect(context.Background(), nodeNames, CollectOptions{Concurrency: 3, NodeTimeout: 50 * time.Millisecond}, func(ctx context.Context, nodeName string) (*kubeletconfigv1beta1.KubeletConfiguration, error) {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for {
+			prev := maxRunning.Load()
+			if cur <= prev || maxRunning.CompareAndSwap(prev, cur) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+
+		switch nodeName {
+		case "worker-3":
+			return nil, errUnreachable
+		case "worker-7":
+			<-ctx.Done() // hangs until the node timeout
+			return nil, ctx.Err()
+		}
+		return &kubeletconfigv1beta1.KubeletConfiguration{CPUManagerPolicy: "static"}, nil
+	})
+
+	if got := maxRunning.Load(); got > 3 {
+		t.Errorf("concurrency not bounded: %d nodes queried at the same time", got)
+	}
+	if len(coll.Configs) != len(nodeNames)-2 || len(coll.Errors) != 2 {
+		t.Fatalf("unexpected collection: %d configs, errors %v", len(coll.Configs), coll.Errors)
+	}
+	if !errors.Is(coll.Errors["worker-3"], errUnreachable) {
+		t.Errorf("unexpected error for worker-3: %v", coll.Errors["worker-3"])
+	}
+	if !errors.Is(coll.Errors["worker-7"], context.DeadlineExceeded) {
+		t.Errorf("unexpected error for worker-7: %v", coll.Errors["worker-7"])
+	}
+}
+
+func TestCollectFromAPIServer(t *testing.T) {
+	srv := newConfigzServer(t, map[string]string{
+		"worker-0": configzTopologyManager,
+		"worker-1": `{"kubeletconfig":`,
+	}, http.StatusOK)
+	cs, err := kubernetes.NewForConfig(&rest.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	coll, err := CollectFromAPIServer(context.Background(), cs, []string{"worker-0", "worker-1", "worker-2"}, CollectOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := coll.Configs["worker-0"]; !ok || len(coll.Configs) != 1 {
+		t.Errorf("unexpected configurations: %v", coll.Configs)
+	}
+	if !errors.Is(coll.Errors["worker-1"], ErrMalformedConfigz) {
+		t.Errorf("unexpected error for worker-1: %v", coll.Errors["worker-1"])
+	}
+	if coll.Errors["worker-2"] == nil {
+		t.Errorf("missing error for worker-2")
+	}
+}
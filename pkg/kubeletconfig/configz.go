@@ -17,7 +17,7 @@ limitations under the License.
 package kubeletconfig
 
 import (
-	"crypto/tls"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -27,62 +27,81 @@ import (
 
 	"github.com/go-logr/logr"
 
+	"k8s.io/client-go/kubernetes"
 	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
 )
 
-func GetKubeletConfigForNodes(kc *Kubectl, nodeNames []string, logger logr.Logger) (k8sconf map[string]*kubeletconfigv1beta1.KubeletConfiguration, err error) {
-	cmd := kc.Command("proxy", "-p", "0")
-	var stdout, stderr io.ReadCloser
-	stdout, stderr, err = StartWithStreamOutput(cmd)
+// GetKubeletConfigForNodesFromAPIServer fetches the kubelet configuration of the given nodes through
+// the nodes/proxy subresource of the apiserver. The nodes whose configuration can't be fetched
+// are skipped, unless the apiserver refuses the requests: this is reported as error, because
+// it affects all the nodes. Use CollectFromAPIServer to get the errors of the nodes.
+func GetKubeletConfigForNodesFromAPIServer(ctx context.Context, cs kubernetes.Interface, nodeNames []string, logger logr.Logger) (map[string]*kubeletconfigv1beta1.KubeletConfiguration, error) {
+	coll, err := CollectFromAPIServer(ctx, cs, nodeNames, CollectOptions{})
 	if err != nil {
 		return nil, err
 	}
-	defer stdout.Close()
-	defer stderr.Close()
-	defer func() { err = cmd.Process.Kill() }()
+	logSkipped(logger, coll)
+	return coll.Configs, nil
+}
+
+// GetKubeletConfigForNodeFromAPIServer fetches the kubelet configuration of the given node through
+// the nodes/proxy subresource of the apiserver.
+func GetKubeletConfigForNodeFromAPIServer(ctx context.Context, cs kubernetes.Interface, nodeName string) (*kubeletconfigv1beta1.KubeletConfiguration, error) {
+	data, err := cs.CoreV1().RESTClient().Get().
+		Resource("nodes").
+		Name(nodeName).
+		SubResource("proxy").
+		Suffix("configz").
+		SetHeader("Accept", "application/json").
+		DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return decodeConfigzData(data)
+}
 
-	port, err := FindProxyPort(stdout)
+// GetKubeletConfigForNodes fetches the kubelet configuration of the given nodes spawning `kubectl proxy`.
+// The nodes whose configuration can't be fetched are skipped. Use GetKubeletConfigForNodesFromAPIServer
+// if possible, which doesn't need the kubectl binary, or CollectFromKubectl to get the errors of the nodes.
+func GetKubeletConfigForNodes(kc *Kubectl, nodeNames []string, logger logr.Logger) (map[string]*kubeletconfigv1beta1.KubeletConfiguration, error) {
+	coll, err := CollectFromKubectl(context.Background(), kc, nodeNames, CollectOptions{}, logger)
 	if err != nil {
 		return nil, err
 	}
-	logger.Info("using proxy", "port", port)
+	logSkipped(logger, coll)
+	return coll.Configs, nil
+}
+
+func logSkipped(logger logr.Logger, coll Collection) {
+	for nodeName, err := range coll.Errors {
+		logger.Info("request failed - skipped", "node", nodeName, "error", err)
+	}
+}
 
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+func getKubeletConfigFromEndpoint(ctx context.Context, client *http.Client, endpoint string) (*kubeletconfigv1beta1.KubeletConfiguration, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
 	}
-	client := &http.Client{Transport: tr}
-
-	k8sconf = make(map[string]*kubeletconfigv1beta1.KubeletConfiguration)
-	for _, nodeName := range nodeNames {
-		endpoint := fmt.Sprintf("http://127.0.0.1:%d/api/v1/nodes/%s/proxy/configz", port, nodeName)
-
-		logger.Info("requesting to proxy", "endpoint", endpoint)
-		req, err := http.NewRequest("GET", endpoint, nil)
-		if err != nil {
-			logger.Info("request creation failed - skipped", "endpoint", endpoint, "error", err)
-			continue
-		}
-		req.Header.Add("Accept", "application/json")
-		resp, err := client.Do(req)
-		if err != nil {
-			logger.Info("request creation failed - skipped", "endpoint", endpoint, "error", err)
-			continue
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode != http.StatusOK {
-			logger.Info("unexpected response status code - skipped", "endpoint", endpoint, "statusCode", resp.StatusCode)
-			continue
-		}
-
-		conf, err := decodeConfigz(resp)
-		if err != nil {
-			logger.Info("response decode failed - skipped", "endpoint", endpoint, "error", err)
-			continue
-		}
-
-		k8sconf[nodeName] = conf
+	req.Header.Add("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
 	}
-	return k8sconf, nil
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{Code: resp.StatusCode}
+	}
+	return decodeConfigz(resp)
+}
+
+// StatusError is reported when the proxy answers with an unexpected HTTP status code.
+type StatusError struct {
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected response status code: %d", e.Code)
 }
 
 func FindProxyPort(r io.Reader) (int, error) {
@@ -104,19 +123,22 @@ func FindProxyPort(r io.Reader) (int, error) {
 }
 
 func decodeConfigz(resp *http.Response) (*kubeletconfigv1beta1.KubeletConfiguration, error) {
-	type configzWrapper struct {
-		ComponentConfig kubeletconfigv1beta1.KubeletConfiguration `json:"kubeletconfig"`
-	}
-
-	configz := configzWrapper{}
 	contentsBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
 	}
+	return decodeConfigzData(contentsBytes)
+}
 
-	err = json.Unmarshal(contentsBytes, &configz)
+func decodeConfigzData(data []byte) (*kubeletconfigv1beta1.KubeletConfiguration, error) {
+	type configzWrapper struct {
+		ComponentConfig kubeletconfigv1beta1.KubeletConfiguration `json:"kubeletconfig"`
+	}
+
+	configz := configzWrapper{}
+	err := json.Unmarshal(data, &configz)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", ErrMalformedConfigz, err)
 	}
 
 	return &configz.ComponentConfig, nil
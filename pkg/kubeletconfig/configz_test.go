@@ -18,7 +18,16 @@ package kubeletconfig
 
 import (
 	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/go-logr/logr/testr"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 func TestFindProxyPort(t *testing.T) {
@@ -64,3 +73,88 @@ func TestFindProxyPort(t *testing.T) {
 		})
 	}
 }
+
+const configzTopologyManager = `{"kubeletconfig":{"cpuManagerPolicy":"static","topologyManagerPolicy":"single-numa-node"}}`
+
+// newConfigzServer serves the configz of the given nodes through the nodes/proxy path of the apiserver.
+// Nodes not in the map are not found.
+func newConfigzServer(t *testing.T, configz map[string]string, status int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status != http.StatusOK {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403}`))
+			return
+		}
+		nodeName, ok := strings.CutPrefix(r.URL.Path, "/api/v1/nodes/")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		nodeName, ok = strings.CutSuffix(nodeName, "/proxy/configz")
+		data, found := configz[nodeName]
+		if !ok || !found {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(data))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestGetKubeletConfigForNodesFromAPIServer(t *testing.T) {
+	srv := newConfigzServer(t, map[string]string{
+		"worker-0": configzTopologyManager,
+		"worker-1": `{"kubeletconfig":`,
+	}, http.StatusOK)
+	cs, err := kubernetes.NewForConfig(&rest.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	confs, err := GetKubeletConfigForNodesFromAPIServer(context.Background(), cs, []string{"worker-0", "worker-1", "worker-2"}, testr.New(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(confs) != 1 {
+		t.Fatalf("unexpected configurations: %v", confs)
+	}
+	conf, ok := confs["worker-0"]
+	if !ok || conf.CPUManagerPolicy != "static" || conf.TopologyManagerPolicy != "single-numa-node" {
+		t.Errorf("unexpected configuration: %+v", conf)
+	}
+}
+
+func TestGetKubeletConfigForNodesFromAPIServerForbidden(t *testing.T) {
+	srv := newConfigzServer(t, nil, http.StatusForbidden)
+	cs, err := kubernetes.NewForConfig(&rest.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = GetKubeletConfigForNodesFromAPIServer(context.Background(), cs, []string{"worker-0"}, testr.New(t))
+	if err == nil {
+		t.Errorf("unexpected success")
+	}
+}
+
+func TestGetKubeletConfigFromEndpoint(t *testing.T) {
+	srv := newConfigzServer(t, map[string]string{
+		"worker-0": configzTopologyManager,
+	}, http.StatusOK)
+
+	conf, err := getKubeletConfigFromEndpoint(context.Background(), srv.Client(), srv.URL+"/api/v1/nodes/worker-0/proxy/configz")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conf.TopologyManagerPolicy != "single-numa-node" {
+		t.Errorf("unexpected configuration: %+v", conf)
+	}
+
+	if _, err := getKubeletConfigFromEndpoint(context.Background(), srv.Client(), srv.URL+"/api/v1/nodes/worker-1/proxy/configz"); err == nil {
+		t.Errorf("unexpected success on missing node")
+	}
+}
@@ -0,0 +1,126 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package kubeletconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+)
+
+const (
+	KubeletConfigurationKind = "KubeletConfiguration"
+)
+
+// the kubelet defaults of the settings the validation checks
+const (
+	DefaultCPUManagerPolicy          = "none"
+	DefaultCPUManagerReconcilePeriod = 10 * time.Second
+	DefaultMemoryManagerPolicy       = kubeletconfigv1beta1.NoneMemoryManagerPolicy
+	DefaultTopologyManagerPolicy     = kubeletconfigv1beta1.NoneTopologyManagerPolicy
+	DefaultTopologyManagerScope      = kubeletconfigv1beta1.ContainerTopologyManagerScope
+)
+
+// DecodeKubeletConfig decodes a kubelet configuration, YAML or JSON. The data can be
+// either a KubeletConfiguration document or a dump of the kubelet configz endpoint.
+// The settings the validation checks and a KubeletConfiguration document omits get
+// the kubelet defaults, like the configz endpoint reports them.
+func DecodeKubeletConfig(data []byte) (*kubeletconfigv1beta1.KubeletConfiguration, error) {
+	var probe struct {
+		Kind          string          `json:"kind"`
+		KubeletConfig *map[string]any `json:"kubeletconfig"`
+	}
+	if err := yaml.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+	if probe.KubeletConfig != nil {
+		jsonData, err := yaml.YAMLToJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		return decodeConfigzData(jsonData)
+	}
+	if probe.Kind != KubeletConfigurationKind {
+		return nil, fmt.Errorf("not a kubelet configuration: kind %q", probe.Kind)
+	}
+	conf := kubeletconfigv1beta1.KubeletConfiguration{}
+	if err := yaml.Unmarshal(data, &conf); err != nil {
+		return nil, err
+	}
+	setDefaults(&conf)
+	return &conf, nil
+}
+
+// setDefaults sets the kubelet defaults of the settings the validation checks, if omitted.
+func setDefaults(conf *kubeletconfigv1beta1.KubeletConfiguration) {
+	if conf.CPUManagerPolicy == "" {
+		conf.CPUManagerPolicy = DefaultCPUManagerPolicy
+	}
+	if conf.CPUManagerReconcilePeriod == (metav1.Duration{}) {
+		conf.CPUManagerReconcilePeriod = metav1.Duration{Duration: DefaultCPUManagerReconcilePeriod}
+	}
+	if conf.MemoryManagerPolicy == "" {
+		conf.MemoryManagerPolicy = DefaultMemoryManagerPolicy
+	}
+	if conf.TopologyManagerPolicy == "" {
+		conf.TopologyManagerPolicy = DefaultTopologyManagerPolicy
+	}
+	if conf.TopologyManagerScope == "" {
+		conf.TopologyManagerScope = DefaultTopologyManagerScope
+	}
+}
+
+// ReadKubeletConfigsFromDir reads the kubelet configuration of the nodes from the files in `dir`
+// with extension .yaml, .yml or .json. Each file holds the configuration of the node named like
+// the file without extension. The other files and the subdirectories are ignored.
+func ReadKubeletConfigsFromDir(dir string) (map[string]*kubeletconfigv1beta1.KubeletConfiguration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	k8sconf := make(map[string]*kubeletconfigv1beta1.KubeletConfiguration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := filepath.Ext(entry.Name())
+		if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+			continue
+		}
+		nodeName := strings.TrimSuffix(entry.Name(), ext)
+		if _, ok := k8sconf[nodeName]; ok {
+			return nil, fmt.Errorf("duplicate configuration for node %q: %s", nodeName, entry.Name())
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		conf, err := DecodeKubeletConfig(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		k8sconf[nodeName] = conf
+	}
+	return k8sconf, nil
+}
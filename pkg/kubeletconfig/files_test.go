@@ -0,0 +1,130 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package kubeletconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDecodeKubeletConfig(t *testing.T) {
+	testCases := []struct {
+		name              string
+		data              string
+		expectedCPUPolicy string
+		expectError       bool
+	}{
+		{
+			name:              "kubelet configuration YAML",
+			data:              "apiVersion: kubelet.config.k8s.io/v1beta1\nkind: KubeletConfiguration\ncpuManagerPolicy: static\n",
+			expectedCPUPolicy: "static",
+		},
+		{
+			name:              "configz JSON",
+			data:              configzTopologyManager,
+			expectedCPUPolicy: "static",
+		},
+		{
+			name:              "configz YAML",
+			data:              "kubeletconfig:\n  cpuManagerPolicy: static\n",
+			expectedCPUPolicy: "static",
+		},
+		{
+			name:        "other kind",
+			data:        "apiVersion: v1\nkind: ConfigMap\n",
+			expectError: true,
+		},
+		{
+			name:        "malformed",
+			data:        "kind: [",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conf, err := DecodeKubeletConfig([]byte(tc.data))
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("unexpected success")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if conf.CPUManagerPolicy != tc.expectedCPUPolicy {
+				t.Errorf("cpuManagerPolicy got %q expected %q", conf.CPUManagerPolicy, tc.expectedCPUPolicy)
+			}
+		})
+	}
+}
+
+func TestDecodeKubeletConfigDefaults(t *testing.T) {
+	conf, err := DecodeKubeletConfig([]byte("apiVersion: kubelet.config.k8s.io/v1beta1\nkind: KubeletConfiguration\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conf.CPUManagerPolicy != DefaultCPUManagerPolicy || conf.CPUManagerReconcilePeriod.Duration != DefaultCPUManagerReconcilePeriod ||
+		conf.MemoryManagerPolicy != DefaultMemoryManagerPolicy || conf.TopologyManagerPolicy != DefaultTopologyManagerPolicy ||
+		conf.TopologyManagerScope != DefaultTopologyManagerScope {
+		t.Errorf("kubelet defaults not applied: %+v", conf)
+	}
+
+	// configz dumps are defaulted by the kubelet already, and reported as they are
+	conf, err = DecodeKubeletConfig([]byte("kubeletconfig:\n  cpuManagerPolicy: static\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conf.TopologyManagerScope != "" || conf.CPUManagerReconcilePeriod.Duration != 0 {
+		t.Errorf("unexpected defaults applied to configz data: %+v", conf)
+	}
+}
+
+func TestReadKubeletConfigsFromDir(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "worker-0.json"), configzTopologyManager)
+	writeFile(t, filepath.Join(dir, "worker-1.yaml"), "apiVersion: kubelet.config.k8s.io/v1beta1\nkind: KubeletConfiguration\ntopologyManagerPolicy: none\n")
+	writeFile(t, filepath.Join(dir, "README.txt"), "ignored")
+	if err := os.Mkdir(filepath.Join(dir, "logs"), 0o755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	confs, err := ReadKubeletConfigsFromDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(confs) != 2 {
+		t.Fatalf("unexpected configurations: %v", confs)
+	}
+	if confs["worker-0"].TopologyManagerPolicy != "single-numa-node" || confs["worker-1"].TopologyManagerPolicy != "none" {
+		t.Errorf("unexpected configurations: %v", confs)
+	}
+
+	writeFile(t, filepath.Join(dir, "worker-0.yaml"), configzTopologyManager)
+	if _, err := ReadKubeletConfigsFromDir(dir); err == nil {
+		t.Errorf("unexpected success with duplicate nodes")
+	}
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
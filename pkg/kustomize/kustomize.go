@@ -0,0 +1,248 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package kustomize
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+)
+
+const (
+	APIVersion  = "kustomize.config.k8s.io/v1beta1"
+	Kind        = "Kustomization"
+	FileName    = "kustomization.yaml"
+	BaseDir     = "base"
+	OverlaysDir = "overlays"
+)
+
+type Kustomization struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Resources  []string `json:"resources"`
+}
+
+// Component groups the rendered objects of a component of the stack
+type Component struct {
+	Name    string
+	Objects []client.Object
+}
+
+// Overlay holds all the components rendered for a platform
+type Overlay struct {
+	Name       string
+	Components []Component
+}
+
+// Tree maps the slash-separated relative paths of the files to their content
+type Tree map[string][]byte
+
+// Paths returns the sorted paths of the files in the tree
+func (tree Tree) Paths() []string {
+	paths := make([]string, 0, len(tree))
+	for p := range tree {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Write writes all the files of the tree under `dir`, creating the directories as needed.
+// Existing files are overwritten.
+func (tree Tree) Write(dir string) error {
+	for _, p := range tree.Paths() {
+		fullPath := filepath.Join(dir, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(fullPath, tree[p], 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Base returns the tree with one directory per component, holding one file per object
+// and the kustomization listing them, and the top-level kustomization listing the components.
+func Base(comps []Component) (Tree, error) {
+	tree := Tree{}
+	var resources []string
+	for _, comp := range comps {
+		files, err := componentFiles(comp)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			continue
+		}
+		var compResources []string
+		for _, file := range files {
+			tree[path.Join(comp.Name, file.name)] = file.data
+			compResources = append(compResources, file.name)
+		}
+		if err := addKustomization(tree, comp.Name, compResources); err != nil {
+			return nil, err
+		}
+		resources = append(resources, comp.Name)
+	}
+	return tree, addKustomization(tree, "", resources)
+}
+
+// WithOverlays returns the tree with a base, holding the objects rendered identically
+// for all the overlays, and one directory per overlay adding the objects specific to it,
+// like the SecurityContextConstraints on OpenShift.
+func WithOverlays(overlays []Overlay) (Tree, error) {
+	if len(overlays) == 0 {
+		return nil, fmt.Errorf("no overlays given")
+	}
+
+	overlayFiles := make([]map[string][]file, len(overlays))
+	for idx, ov := range overlays {
+		overlayFiles[idx] = make(map[string][]file)
+		for _, comp := range ov.Components {
+			files, err := componentFiles(comp)
+			if err != nil {
+				return nil, err
+			}
+			overlayFiles[idx][comp.Name] = files
+		}
+	}
+
+	// the base holds the objects all the overlays render the same way
+	isCommon := func(compName string, fl file) bool {
+		for idx := range overlays {
+			found := false
+			for _, other := range overlayFiles[idx][compName] {
+				if other.name == fl.name && bytes.Equal(other.data, fl.data) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+
+	tree := Tree{}
+	var baseResources []string
+	common := make(map[string]bool)
+	for _, comp := range overlays[0].Components {
+		var compResources []string
+		for _, fl := range overlayFiles[0][comp.Name] {
+			if !isCommon(comp.Name, fl) {
+				continue
+			}
+			common[path.Join(comp.Name, fl.name)] = true
+			tree[path.Join(BaseDir, comp.Name, fl.name)] = fl.data
+			compResources = append(compResources, fl.name)
+		}
+		if len(compResources) == 0 {
+			continue
+		}
+		if err := addKustomization(tree, path.Join(BaseDir, comp.Name), compResources); err != nil {
+			return nil, err
+		}
+		baseResources = append(baseResources, comp.Name)
+	}
+	if err := addKustomization(tree, BaseDir, baseResources); err != nil {
+		return nil, err
+	}
+
+	for idx, ov := range overlays {
+		ovDir := path.Join(OverlaysDir, ov.Name)
+		resources := []string{"../../" + BaseDir}
+		for _, comp := range ov.Components {
+			for _, fl := range overlayFiles[idx][comp.Name] {
+				p := path.Join(comp.Name, fl.name)
+				if common[p] {
+					continue
+				}
+				tree[path.Join(ovDir, p)] = fl.data
+				resources = append(resources, p)
+			}
+		}
+		if err := addKustomization(tree, ovDir, resources); err != nil {
+			return nil, err
+		}
+	}
+	return tree, nil
+}
+
+type file struct {
+	name string
+	data []byte
+}
+
+func componentFiles(comp Component) ([]file, error) {
+	var files []file
+	seen := make(map[string]bool)
+	for _, obj := range comp.Objects {
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		if kind == "" {
+			return nil, fmt.Errorf("component %s: object %q without kind", comp.Name, obj.GetName())
+		}
+		name := ObjectFileName(kind, obj.GetName())
+		if seen[name] {
+			name = ObjectFileName(kind, obj.GetNamespace()+"-"+obj.GetName())
+		}
+		seen[name] = true
+		data, err := manifests.SerializeObjectToData(obj)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file{name: name, data: data})
+	}
+	return files, nil
+}
+
+// ObjectFileName returns the name of the file holding the object with the given kind and name
+func ObjectFileName(kind, name string) string {
+	name = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '.' {
+			return r
+		}
+		return '-'
+	}, strings.ToLower(name))
+	return strings.ToLower(kind) + "-" + name + ".yaml"
+}
+
+func addKustomization(tree Tree, dir string, resources []string) error {
+	if resources == nil {
+		resources = []string{}
+	}
+	data, err := yaml.Marshal(Kustomization{
+		APIVersion: APIVersion,
+		Kind:       Kind,
+		Resources:  resources,
+	})
+	if err != nil {
+		return err
+	}
+	tree[path.Join(dir, FileName)] = data
+	return nil
+}
@@ -0,0 +1,239 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package kustomize
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+func TestBase(t *testing.T) {
+	type testCase struct {
+		name          string
+		comps         []Component
+		expectedPaths []string
+		expectedRes   map[string][]string
+	}
+
+	testCases := []testCase{
+		{
+			name:          "no components",
+			expectedPaths: []string{FileName},
+			expectedRes: map[string][]string{
+				FileName: {},
+			},
+		},
+		{
+			name: "empty component skipped",
+			comps: []Component{
+				{Name: "api", Objects: []client.Object{makeNamespace("tas")}},
+				{Name: "sched"},
+			},
+			expectedPaths: []string{
+				"api/kustomization.yaml",
+				"api/namespace-tas.yaml",
+				FileName,
+			},
+			expectedRes: map[string][]string{
+				FileName:                 {"api"},
+				"api/kustomization.yaml": {"namespace-tas.yaml"},
+			},
+		},
+		{
+			name: "name clash and sanitized names",
+			comps: []Component{
+				{
+					Name: "rte",
+					Objects: []client.Object{
+						makeConfigMap("ns1", "rte-config", "a"),
+						makeConfigMap("ns2", "rte-config", "a"),
+						makeConfigMap("", "system:Config", "a"),
+					},
+				},
+			},
+			expectedPaths: []string{
+				FileName,
+				"rte/configmap-ns2-rte-config.yaml",
+				"rte/configmap-rte-config.yaml",
+				"rte/configmap-system-config.yaml",
+				"rte/kustomization.yaml",
+			},
+			expectedRes: map[string][]string{
+				FileName: {"rte"},
+				"rte/kustomization.yaml": {
+					"configmap-rte-config.yaml",
+					"configmap-ns2-rte-config.yaml",
+					"configmap-system-config.yaml",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tree, err := Base(tc.comps)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := tree.Paths(); !reflect.DeepEqual(got, tc.expectedPaths) {
+				t.Errorf("paths: got %v expected %v", got, tc.expectedPaths)
+			}
+			checkResources(t, tree, tc.expectedRes)
+		})
+	}
+}
+
+func TestBaseMissingKind(t *testing.T) {
+	cm := makeConfigMap("ns", "cm", "a")
+	cm.TypeMeta = metav1.TypeMeta{}
+	_, err := Base([]Component{{Name: "api", Objects: []client.Object{cm}}})
+	if err == nil {
+		t.Fatalf("expected error for object without kind")
+	}
+}
+
+func TestWithOverlays(t *testing.T) {
+	type testCase struct {
+		name          string
+		overlays      []Overlay
+		expectedErr   bool
+		expectedPaths []string
+		expectedRes   map[string][]string
+	}
+
+	testCases := []testCase{
+		{
+			name:        "no overlays",
+			expectedErr: true,
+		},
+		{
+			name: "common and specific objects",
+			overlays: []Overlay{
+				{
+					Name: "kubernetes",
+					Components: []Component{
+						{Name: "api", Objects: []client.Object{makeNamespace("tas")}},
+						{Name: "rte", Objects: []client.Object{makeConfigMap("tas", "cfg", "k8s"), makeConfigMap("tas", "common", "a")}},
+					},
+				},
+				{
+					Name: "openshift",
+					Components: []Component{
+						{Name: "api", Objects: []client.Object{makeNamespace("tas")}},
+						{Name: "rte", Objects: []client.Object{makeConfigMap("tas", "cfg", "ocp"), makeConfigMap("tas", "common", "a"), makeConfigMap("tas", "scc", "a")}},
+					},
+				},
+			},
+			expectedPaths: []string{
+				"base/api/kustomization.yaml",
+				"base/api/namespace-tas.yaml",
+				"base/kustomization.yaml",
+				"base/rte/configmap-common.yaml",
+				"base/rte/kustomization.yaml",
+				"overlays/kubernetes/kustomization.yaml",
+				"overlays/kubernetes/rte/configmap-cfg.yaml",
+				"overlays/openshift/kustomization.yaml",
+				"overlays/openshift/rte/configmap-cfg.yaml",
+				"overlays/openshift/rte/configmap-scc.yaml",
+			},
+			expectedRes: map[string][]string{
+				"base/kustomization.yaml":                {"api", "rte"},
+				"base/api/kustomization.yaml":            {"namespace-tas.yaml"},
+				"base/rte/kustomization.yaml":            {"configmap-common.yaml"},
+				"overlays/kubernetes/kustomization.yaml": {"../../base", "rte/configmap-cfg.yaml"},
+				"overlays/openshift/kustomization.yaml":  {"../../base", "rte/configmap-cfg.yaml", "rte/configmap-scc.yaml"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tree, err := WithOverlays(tc.overlays)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("error: got %v expected error %v", err, tc.expectedErr)
+			}
+			if tc.expectedErr {
+				return
+			}
+			if got := tree.Paths(); !reflect.DeepEqual(got, tc.expectedPaths) {
+				t.Errorf("paths: got %v expected %v", got, tc.expectedPaths)
+			}
+			checkResources(t, tree, tc.expectedRes)
+		})
+	}
+}
+
+func TestTreeWrite(t *testing.T) {
+	tree, err := Base([]Component{
+		{Name: "api", Objects: []client.Object{makeNamespace("tas")}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dir := t.TempDir()
+	if err := tree.Write(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, p := range tree.Paths() {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(p)))
+		if err != nil {
+			t.Fatalf("cannot read %s: %v", p, err)
+		}
+		if string(data) != string(tree[p]) {
+			t.Errorf("%s: content mismatch", p)
+		}
+	}
+}
+
+func checkResources(t *testing.T, tree Tree, expected map[string][]string) {
+	t.Helper()
+	for p, res := range expected {
+		kust := Kustomization{}
+		if err := yaml.Unmarshal(tree[p], &kust); err != nil {
+			t.Fatalf("%s: cannot decode: %v", p, err)
+		}
+		if kust.APIVersion != APIVersion || kust.Kind != Kind {
+			t.Errorf("%s: unexpected type %s %s", p, kust.APIVersion, kust.Kind)
+		}
+		if !reflect.DeepEqual(kust.Resources, res) {
+			t.Errorf("%s: resources got %v expected %v", p, kust.Resources, res)
+		}
+	}
+}
+
+func makeNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+}
+
+func makeConfigMap(namespace, name, value string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Data:       map[string]string{"value": value},
+	}
+}
@@ -22,6 +22,8 @@ import (
 	"fmt"
 	"io"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	k8sjson "k8s.io/apimachinery/pkg/runtime/serializer/json"
@@ -30,15 +32,23 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func SerializeObject(obj runtime.Object, out io.Writer) error {
+const (
+	OutputYAML      = "yaml"
+	OutputJSON      = "json"
+	OutputJSONLines = "jsonl"
+)
+
+// NormalizeObject returns the unstructured representation of `obj`, without
+// the fields (status, creation timestamps) which never belong to a manifest.
+func NormalizeObject(obj runtime.Object) (*unstructured.Unstructured, error) {
 	jsonBytes, err := json.Marshal(obj)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var r unstructured.Unstructured
 	if err := json.Unmarshal(jsonBytes, &r.Object); err != nil {
-		return err
+		return nil, err
 	}
 
 	// remove status and metadata.creationTimestamp
@@ -46,9 +56,17 @@ func SerializeObject(obj runtime.Object, out io.Writer) error {
 	unstructured.RemoveNestedField(r.Object, "template", "metadata", "creationTimestamp")
 	unstructured.RemoveNestedField(r.Object, "spec", "template", "metadata", "creationTimestamp")
 	unstructured.RemoveNestedField(r.Object, "status")
+	return &r, nil
+}
+
+func SerializeObject(obj runtime.Object, out io.Writer) error {
+	r, err := NormalizeObject(obj)
+	if err != nil {
+		return err
+	}
 
 	srz := k8sjson.NewYAMLSerializer(k8sjson.DefaultMetaFactory, k8sscheme.Scheme, k8sscheme.Scheme)
-	return srz.Encode(&r, out)
+	return srz.Encode(r, out)
 }
 
 func SerializeObjectToData(obj runtime.Object) ([]byte, error) {
@@ -84,3 +102,60 @@ func RenderObjects(objs []client.Object, w io.Writer) error {
 
 	return nil
 }
+
+// RenderObjectsJSON renders `objs` as a single v1.List, normalized as RenderObjects does.
+func RenderObjectsJSON(objs []client.Object, w io.Writer) error {
+	list := corev1.List{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "List",
+		},
+		Items: make([]runtime.RawExtension, 0, len(objs)),
+	}
+	for _, obj := range objs {
+		r, err := NormalizeObject(obj)
+		if err != nil {
+			return err
+		}
+		list.Items = append(list.Items, runtime.RawExtension{Object: r})
+	}
+
+	data, err := json.MarshalIndent(list, "", "    ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+// RenderObjectsJSONLines renders `objs` one per line, normalized as RenderObjects does.
+func RenderObjectsJSONLines(objs []client.Object, w io.Writer) error {
+	for _, obj := range objs {
+		r, err := NormalizeObject(obj)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(r.Object)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderObjectsAs renders `objs` in the given `output` format, one of OutputYAML, OutputJSON, OutputJSONLines.
+func RenderObjectsAs(objs []client.Object, output string, w io.Writer) error {
+	switch output {
+	case OutputYAML:
+		return RenderObjects(objs, w)
+	case OutputJSON:
+		return RenderObjectsJSON(objs, w)
+	case OutputJSONLines:
+		return RenderObjectsJSONLines(objs, w)
+	default:
+		return fmt.Errorf("unsupported output: %q", output)
+	}
+}
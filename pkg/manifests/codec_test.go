@@ -0,0 +1,111 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package manifests
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func codecTestObjects() []client.Object {
+	return []client.Object{
+		&corev1.Namespace{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{Name: "ns"},
+			Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+		},
+		&appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{Name: "dp", Namespace: "ns"},
+		},
+	}
+}
+
+func checkNormalized(t *testing.T, obj map[string]interface{}) {
+	t.Helper()
+	if _, ok := obj["status"]; ok {
+		t.Errorf("status not removed from %v", obj)
+	}
+	md, _ := obj["metadata"].(map[string]interface{})
+	if _, ok := md["creationTimestamp"]; ok {
+		t.Errorf("creationTimestamp not removed from %v", obj)
+	}
+}
+
+func TestRenderObjectsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderObjectsAs(codecTestObjects(), OutputJSON, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var list struct {
+		APIVersion string                   `json:"apiVersion"`
+		Kind       string                   `json:"kind"`
+		Items      []map[string]interface{} `json:"items"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &list); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if list.APIVersion != "v1" || list.Kind != "List" {
+		t.Errorf("unexpected list type: %s/%s", list.APIVersion, list.Kind)
+	}
+	kinds := []string{}
+	for _, item := range list.Items {
+		checkNormalized(t, item)
+		kinds = append(kinds, item["kind"].(string))
+	}
+	if got := strings.Join(kinds, ","); got != "Namespace,Deployment" {
+		t.Errorf("unexpected items: %s", got)
+	}
+}
+
+func TestRenderObjectsJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderObjectsAs(codecTestObjects(), OutputJSONLines, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	kinds := []string{}
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var obj map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &obj); err != nil {
+			t.Fatalf("line is not valid JSON: %v\n%s", err, scanner.Text())
+		}
+		checkNormalized(t, obj)
+		kinds = append(kinds, obj["kind"].(string))
+	}
+	if got := strings.Join(kinds, ","); got != "Namespace,Deployment" {
+		t.Errorf("unexpected lines: %s", got)
+	}
+}
+
+func TestRenderObjectsAsUnsupported(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderObjectsAs(codecTestObjects(), "xml", &buf); err == nil {
+		t.Errorf("expected error for unsupported output")
+	}
+}
@@ -0,0 +1,154 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package manifests
+
+import (
+	"reflect"
+)
+
+// ObjectMatches tells if all the fields set in the `desired` object have
+// the same value in the `live` object. Fields only set in `live`, like the
+// ones defaulted or managed by the apiserver, are ignored. Lists must have
+// the same length and are compared element by element.
+func ObjectMatches(desired, live map[string]interface{}) bool {
+	return valueMatches(desired, live)
+}
+
+// PruneObject returns a copy of the `live` object holding only the fields
+// which are also set in the `desired` object, so the two can be compared
+// without the noise of the fields defaulted or managed by the apiserver.
+// Empty fields set in `desired` and missing in `live` are copied over,
+// consistently with ObjectMatches.
+func PruneObject(live, desired map[string]interface{}) map[string]interface{} {
+	ret, ok := pruneValue(live, desired).(map[string]interface{})
+	if !ok {
+		return map[string]interface{}{}
+	}
+	return ret
+}
+
+func pruneValue(live, desired interface{}) interface{} {
+	switch dv := desired.(type) {
+	case map[string]interface{}:
+		lv, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		ret := make(map[string]interface{})
+		for key, dItem := range dv {
+			lItem, ok := lv[key]
+			if !ok {
+				if isEmptyValue(dItem) {
+					ret[key] = dItem
+				}
+				continue
+			}
+			ret[key] = pruneValue(lItem, dItem)
+		}
+		return ret
+	case []interface{}:
+		lv, ok := live.([]interface{})
+		if !ok {
+			return live
+		}
+		ret := make([]interface{}, len(lv))
+		for idx := range lv {
+			if idx < len(dv) {
+				ret[idx] = pruneValue(lv[idx], dv[idx])
+			} else {
+				ret[idx] = lv[idx]
+			}
+		}
+		return ret
+	}
+	return live
+}
+
+func valueMatches(desired, live interface{}) bool {
+	switch dv := desired.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		lv, ok := live.(map[string]interface{})
+		if !ok {
+			return len(dv) == 0 && live == nil
+		}
+		for key, dItem := range dv {
+			lItem, ok := lv[key]
+			if !ok {
+				if isEmptyValue(dItem) {
+					continue
+				}
+				return false
+			}
+			if !valueMatches(dItem, lItem) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		lv, ok := live.([]interface{})
+		if !ok {
+			return len(dv) == 0 && live == nil
+		}
+		if len(dv) != len(lv) {
+			return false
+		}
+		for idx := range dv {
+			if !valueMatches(dv[idx], lv[idx]) {
+				return false
+			}
+		}
+		return true
+	}
+	// json decoding gives float64, the apiserver gives int64
+	if dNum, ok := toFloat64(desired); ok {
+		lNum, ok := toFloat64(live)
+		return ok && dNum == lNum
+	}
+	return reflect.DeepEqual(desired, live)
+}
+
+func isEmptyValue(val interface{}) bool {
+	switch v := val.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	case string:
+		return v == ""
+	}
+	return false
+}
+
+func toFloat64(val interface{}) (float64, bool) {
+	switch v := val.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
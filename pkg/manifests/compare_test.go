@@ -0,0 +1,151 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package manifests
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestObjectMatches(t *testing.T) {
+	type testCase struct {
+		name     string
+		desired  map[string]interface{}
+		live     map[string]interface{}
+		expected bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "identical",
+			desired: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "foo"},
+			},
+			live: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "foo"},
+			},
+			expected: true,
+		},
+		{
+			name: "server-side fields ignored",
+			desired: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "foo"},
+				"spec":     map[string]interface{}{"replicas": float64(2)},
+			},
+			live: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "foo", "uid": "1234", "resourceVersion": "42"},
+				"spec":     map[string]interface{}{"replicas": int64(2), "revisionHistoryLimit": int64(10)},
+			},
+			expected: true,
+		},
+		{
+			name: "empty desired fields missing in live",
+			desired: map[string]interface{}{
+				"spec": map[string]interface{}{"resources": map[string]interface{}{}},
+			},
+			live: map[string]interface{}{
+				"spec": map[string]interface{}{},
+			},
+			expected: true,
+		},
+		{
+			name: "changed value",
+			desired: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "foo", "image": "quay.io/foo:v2"},
+					},
+				},
+			},
+			live: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "foo", "image": "quay.io/foo:v1", "imagePullPolicy": "Always"},
+					},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "missing list item",
+			desired: map[string]interface{}{
+				"data": []interface{}{"a", "b"},
+			},
+			live: map[string]interface{}{
+				"data": []interface{}{"a"},
+			},
+			expected: false,
+		},
+		{
+			name: "missing field",
+			desired: map[string]interface{}{
+				"data": map[string]interface{}{"config.yaml": "foo: bar"},
+			},
+			live: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "foo"},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ObjectMatches(tc.desired, tc.live)
+			if got != tc.expected {
+				t.Errorf("match got %v expected %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestPruneObject(t *testing.T) {
+	desired := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "foo"},
+		"spec": map[string]interface{}{
+			"resources": map[string]interface{}{},
+			"containers": []interface{}{
+				map[string]interface{}{"name": "foo", "image": "quay.io/foo:v2"},
+			},
+		},
+	}
+	live := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "foo", "uid": "1234"},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "foo", "image": "quay.io/foo:v1", "imagePullPolicy": "Always"},
+				map[string]interface{}{"name": "bar", "image": "quay.io/bar:v1"},
+			},
+			"revisionHistoryLimit": int64(10),
+		},
+		"status": map[string]interface{}{"replicas": int64(1)},
+	}
+	expected := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "foo"},
+		"spec": map[string]interface{}{
+			"resources": map[string]interface{}{},
+			"containers": []interface{}{
+				map[string]interface{}{"name": "foo", "image": "quay.io/foo:v1"},
+				map[string]interface{}{"name": "bar", "image": "quay.io/bar:v1"},
+			},
+		},
+	}
+
+	got := PruneObject(live, desired)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("pruned object mismatch\ngot=%v\nexpected=%v", got, expected)
+	}
+}
@@ -0,0 +1,149 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package manifests
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	IndexFileName = "index.yaml"
+)
+
+// IndexEntry describes a file holding a rendered object
+type IndexEntry struct {
+	Order      int    `json:"order"`
+	File       string `json:"file"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	SHA256     string `json:"sha256"`
+}
+
+// Index lists the files of the rendered objects, in apply order
+type Index struct {
+	Objects []IndexEntry `json:"objects"`
+}
+
+// RenderObjectsToDir writes one file per object in `dir`, named <order>-<kind>-<namespace>-<name>.yaml
+// (<order>-<kind>-<name>.yaml for cluster-scoped objects), so applying the files in name order
+// creates the objects in the given order, plus the index of the files.
+// Files listed in a previous index but no longer rendered are removed.
+func RenderObjectsToDir(objs []client.Object, dir string) (Index, error) {
+	idx := Index{}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return idx, err
+	}
+	oldIdx, err := LoadIndex(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return idx, err
+	}
+
+	width := len(fmt.Sprintf("%d", len(objs)))
+	if width < 3 {
+		width = 3
+	}
+	files := make(map[string]bool)
+	seen := make(map[string]bool)
+	for pos, obj := range objs {
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		if gvk.Kind == "" {
+			return idx, fmt.Errorf("object %q without kind", obj.GetName())
+		}
+		data, err := SerializeObjectToData(obj)
+		if err != nil {
+			return idx, err
+		}
+		entry := IndexEntry{
+			Order:      pos + 1,
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+		}
+		key := entry.APIVersion + "/" + entry.Kind + "/" + entry.Namespace + "/" + entry.Name
+		if seen[key] {
+			return idx, fmt.Errorf("duplicate object %s %s/%s", entry.Kind, entry.Namespace, entry.Name)
+		}
+		seen[key] = true
+		entry.File = objectFileName(width, entry)
+		files[entry.File] = true
+		sum := sha256.Sum256(data)
+		entry.SHA256 = hex.EncodeToString(sum[:])
+		if err := os.WriteFile(filepath.Join(dir, entry.File), data, 0644); err != nil {
+			return idx, err
+		}
+		idx.Objects = append(idx.Objects, entry)
+	}
+
+	for _, entry := range oldIdx.Objects {
+		if files[entry.File] || entry.File != filepath.Base(entry.File) {
+			continue
+		}
+		err := os.Remove(filepath.Join(dir, entry.File))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return idx, err
+		}
+	}
+
+	data, err := yaml.Marshal(idx)
+	if err != nil {
+		return idx, err
+	}
+	return idx, os.WriteFile(filepath.Join(dir, IndexFileName), data, 0644)
+}
+
+// LoadIndex reads the index from `dir`
+func LoadIndex(dir string) (Index, error) {
+	idx := Index{}
+	data, err := os.ReadFile(filepath.Join(dir, IndexFileName))
+	if err != nil {
+		return idx, err
+	}
+	if err := yaml.Unmarshal(data, &idx); err != nil {
+		return idx, fmt.Errorf("cannot decode %s: %w", IndexFileName, err)
+	}
+	return idx, nil
+}
+
+func objectFileName(width int, entry IndexEntry) string {
+	tokens := []string{fmt.Sprintf("%0*d", width, entry.Order), entry.Kind}
+	if entry.Namespace != "" {
+		tokens = append(tokens, entry.Namespace)
+	}
+	tokens = append(tokens, entry.Name)
+	return sanitizeFileName(strings.Join(tokens, "-")) + ".yaml"
+}
+
+func sanitizeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '.' {
+			return r
+		}
+		return '-'
+	}, strings.ToLower(name))
+}
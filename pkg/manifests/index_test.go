@@ -0,0 +1,175 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package manifests
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRenderObjectsToDir(t *testing.T) {
+	type testCase struct {
+		name          string
+		objs          []client.Object
+		expectedFiles []string
+		expectedErr   bool
+	}
+
+	testCases := []testCase{
+		{
+			name:          "no objects",
+			expectedFiles: []string{IndexFileName},
+		},
+		{
+			name: "namespaced and cluster-scoped objects",
+			objs: []client.Object{
+				makeTestNamespace("tas"),
+				makeTestClusterRole("system:tas"),
+				makeTestServiceAccount("tas", "rte"),
+			},
+			expectedFiles: []string{
+				"001-namespace-tas.yaml",
+				"002-clusterrole-system-tas.yaml",
+				"003-serviceaccount-tas-rte.yaml",
+				IndexFileName,
+			},
+		},
+		{
+			name: "duplicate objects",
+			objs: []client.Object{
+				makeTestNamespace("tas"),
+				makeTestNamespace("tas"),
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			idx, err := RenderObjectsToDir(tc.objs, dir)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("error: got %v expected error %v", err, tc.expectedErr)
+			}
+			if tc.expectedErr {
+				return
+			}
+			if got := listFiles(t, dir); !reflect.DeepEqual(got, tc.expectedFiles) {
+				t.Errorf("files: got %v expected %v", got, tc.expectedFiles)
+			}
+
+			loaded, err := LoadIndex(dir)
+			if err != nil {
+				t.Fatalf("cannot load index: %v", err)
+			}
+			if !reflect.DeepEqual(loaded, idx) {
+				t.Errorf("index mismatch: got %+v expected %+v", loaded, idx)
+			}
+			for pos, entry := range idx.Objects {
+				if entry.Order != pos+1 {
+					t.Errorf("%s: order %d expected %d", entry.File, entry.Order, pos+1)
+				}
+				data, err := os.ReadFile(filepath.Join(dir, entry.File))
+				if err != nil {
+					t.Fatalf("cannot read %s: %v", entry.File, err)
+				}
+				sum := sha256.Sum256(data)
+				if entry.SHA256 != hex.EncodeToString(sum[:]) {
+					t.Errorf("%s: hash mismatch", entry.File)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderObjectsToDirRemovesStaleFiles(t *testing.T) {
+	dir := t.TempDir()
+	_, err := RenderObjectsToDir([]client.Object{
+		makeTestNamespace("tas"),
+		makeTestServiceAccount("tas", "rte"),
+	}, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// unrelated files are preserved
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("notes"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = RenderObjectsToDir([]client.Object{
+		makeTestNamespace("tas"),
+		makeTestClusterRole("rte"),
+	}, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{
+		"001-namespace-tas.yaml",
+		"002-clusterrole-rte.yaml",
+		"README.md",
+		IndexFileName,
+	}
+	if got := listFiles(t, dir); !reflect.DeepEqual(got, expected) {
+		t.Errorf("files: got %v expected %v", got, expected)
+	}
+}
+
+func listFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("cannot read dir: %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func makeTestNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+}
+
+func makeTestServiceAccount(namespace, name string) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+	}
+}
+
+func makeTestClusterRole(name string) *rbacv1.ClusterRole {
+	return &rbacv1.ClusterRole{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole"},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+}
@@ -0,0 +1,118 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package manifests
+
+import (
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// the well-known labels, see https://kubernetes.io/docs/concepts/overview/working-with-objects/common-labels/
+const (
+	LabelAppName      = "app.kubernetes.io/name"
+	LabelAppInstance  = "app.kubernetes.io/instance"
+	LabelAppVersion   = "app.kubernetes.io/version"
+	LabelAppComponent = "app.kubernetes.io/component"
+	LabelAppPartOf    = "app.kubernetes.io/part-of"
+	LabelAppManagedBy = "app.kubernetes.io/managed-by"
+)
+
+const (
+	PartOf           = "topology-aware-scheduling"
+	ManagedBy        = "topology-aware-scheduling-deployer"
+	DefaultInstallID = "default"
+)
+
+// DeployerVersion is the version reported in the LabelAppVersion label.
+// Binaries are expected to set it to their own version at startup.
+var DeployerVersion = "unknown"
+
+var appNames = map[string]string{
+	ComponentAPI:                      "noderesourcetopology-api",
+	ComponentSchedulerPlugin:          "topology-aware-scheduler",
+	ComponentResourceTopologyExporter: "resource-topology-exporter",
+	ComponentNodeFeatureDiscovery:     "nfd-topology-updater",
+}
+
+// OwnershipLabels returns the labels identifying the objects of the given
+// component, created by the deployer for the installation `installID`.
+func OwnershipLabels(component, installID string) map[string]string {
+	if installID == "" {
+		installID = DefaultInstallID
+	}
+	name, ok := appNames[component]
+	if !ok {
+		name = component
+	}
+	return map[string]string{
+		LabelAppName:      name,
+		LabelAppInstance:  installID,
+		LabelAppVersion:   labelValue(DeployerVersion),
+		LabelAppComponent: component,
+		LabelAppPartOf:    PartOf,
+		LabelAppManagedBy: ManagedBy,
+	}
+}
+
+// InstallSelector returns the labels selecting all the objects
+// created by the deployer for the installation `installID`.
+func InstallSelector(installID string) map[string]string {
+	if installID == "" {
+		installID = DefaultInstallID
+	}
+	return map[string]string{
+		LabelAppInstance:  installID,
+		LabelAppManagedBy: ManagedBy,
+	}
+}
+
+// StampObject adds the ownership labels to the object metadata, preserving
+// its existing labels. Selectors and pod templates are left untouched: they
+// are immutable or would cause needless rollouts.
+func StampObject(obj client.Object, component, installID string) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	for key, value := range OwnershipLabels(component, installID) {
+		labels[key] = value
+	}
+	obj.SetLabels(labels)
+}
+
+// StampObjects is like StampObject for all the given objects, which are returned for convenience.
+func StampObjects(objs []client.Object, component, installID string) []client.Object {
+	for _, obj := range objs {
+		StampObject(obj, component, installID)
+	}
+	return objs
+}
+
+// labelValue makes `value` a valid label value, replacing the unsupported characters
+func labelValue(value string) string {
+	ret := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '-'
+	}, value)
+	if len(ret) > 63 {
+		ret = ret[:63]
+	}
+	return strings.Trim(ret, "-_.")
+}
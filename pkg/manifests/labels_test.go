@@ -0,0 +1,101 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package manifests
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStampObject(t *testing.T) {
+	type testCase struct {
+		name      string
+		labels    map[string]string
+		installID string
+		expected  map[string]string
+	}
+
+	testCases := []testCase{
+		{
+			name:      "no labels",
+			installID: "tas1",
+			expected: map[string]string{
+				LabelAppName:      "resource-topology-exporter",
+				LabelAppInstance:  "tas1",
+				LabelAppVersion:   "unknown",
+				LabelAppComponent: ComponentResourceTopologyExporter,
+				LabelAppPartOf:    PartOf,
+				LabelAppManagedBy: ManagedBy,
+			},
+		},
+		{
+			name:   "existing labels preserved, default install",
+			labels: map[string]string{"name": "resource-topology"},
+			expected: map[string]string{
+				"name":            "resource-topology",
+				LabelAppName:      "resource-topology-exporter",
+				LabelAppInstance:  DefaultInstallID,
+				LabelAppVersion:   "unknown",
+				LabelAppComponent: ComponentResourceTopologyExporter,
+				LabelAppPartOf:    PartOf,
+				LabelAppManagedBy: ManagedBy,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "foo",
+					Labels: tc.labels,
+				},
+			}
+			StampObject(cm, ComponentResourceTopologyExporter, tc.installID)
+			if !reflect.DeepEqual(cm.Labels, tc.expected) {
+				t.Errorf("got labels %v expected %v", cm.Labels, tc.expected)
+			}
+		})
+	}
+}
+
+func TestLabelValue(t *testing.T) {
+	type testCase struct {
+		value    string
+		expected string
+	}
+
+	testCases := []testCase{
+		{value: "v0.21.0", expected: "v0.21.0"},
+		{value: "v0.21.0-5-gabcdef+dirty", expected: "v0.21.0-5-gabcdef-dirty"},
+		{value: "(devel)", expected: "devel"},
+		{value: strings.Repeat("a", 70), expected: strings.Repeat("a", 63)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			got := labelValue(tc.value)
+			if got != tc.expected {
+				t.Errorf("got %q expected %q", got, tc.expected)
+			}
+		})
+	}
+}
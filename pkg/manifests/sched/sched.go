@@ -114,44 +114,48 @@ func (mf Manifests) Render(logger logr.Logger, opts options.Scheduler) (Manifest
 	ret.DPScheduler.Spec.Replicas = newInt32(replicas)
 	ret.DPController.Spec.Replicas = newInt32(replicas)
 
-	params := manifests.ConfigParams{
-		ProfileName: opts.ProfileName,
-		Cache:       manifests.NewConfigCacheParams(),
+	leap, ok, err := leaderElectionParamsFromOpts(opts)
+	if err != nil {
+		return ret, err
 	}
 
-	leap, ok, err := leaderElectionParamsFromOpts(opts)
+	params, err := configParamsFromOpts(opts, options.SchedulerProfile{Name: opts.ProfileName})
 	if err != nil {
 		return ret, err
 	}
 	if ok {
 		params.LeaderElection = &leap
 	}
-
-	if len(opts.CacheParamsConfigData) > 0 {
-		err = yaml.Unmarshal([]byte(opts.CacheParamsConfigData), params.Cache)
-		if err != nil {
-			return ret, err
-		}
+	params.APIVersion, err = manifests.SchedulerConfigAPIVersion(opts.ConfigAPIVersion, mf.plat, opts.PlatformVersion)
+	if err != nil {
+		return ret, err
 	}
+	if params.APIVersion == "" {
+		logger.V(4).Info("unknown platform version, keeping the scheduler config version", "platform", mf.plat)
+	}
+	profileParams := []manifests.ConfigParams{params}
 
-	// always override
-	params.Cache.ResyncPeriodSeconds = newInt64(int64(opts.CacheResyncPeriod.Seconds()))
-
-	if len(opts.ScoringStratConfigData) > 0 {
-		params.ScoringStrategy = &manifests.ScoringStrategyParams{}
-		err = yaml.Unmarshal([]byte(opts.ScoringStratConfigData), params.ScoringStrategy)
+	for _, prof := range opts.Profiles {
+		if prof.Name == "" {
+			return ret, fmt.Errorf("missing scheduler profile name")
+		}
+		params, err := configParamsFromOpts(opts, prof)
 		if err != nil {
-			return ret, err
+			return ret, fmt.Errorf("scheduler profile %q: %w", prof.Name, err)
 		}
+		params.LeaderElection = profileParams[0].LeaderElection
+		profileParams = append(profileParams, params)
 	}
 
-	err = schedupdate.SchedulerConfig(ret.ConfigMap, DefaultProfileName, &params)
+	err = schedupdate.SchedulerConfigProfiles(ret.ConfigMap, DefaultProfileName, profileParams)
 	if err != nil {
 		return ret, err
 	}
 
 	schedupdate.SchedulerDeployment(ret.DPScheduler, opts.PullIfNotPresent, opts.CtrlPlaneAffinity, opts.Verbose)
 	schedupdate.ControllerDeployment(ret.DPController, opts.PullIfNotPresent, opts.CtrlPlaneAffinity)
+	schedupdate.PodSettings(ret.DPScheduler, opts.SchedulerPod)
+	schedupdate.PodSettings(ret.DPController, opts.ControllerPod)
 	if opts.Namespace != "" {
 		ret.Namespace.Name = opts.Namespace
 	} else if mf.plat == platform.OpenShift || mf.plat == platform.HyperShift {
@@ -301,6 +305,91 @@ func GetManifests(plat platform.Platform, namespace string) (Manifests, error) {
 	})
 }
 
+// configParamsFromOpts returns the configuration of the scheduler profile `prof`.
+// The configuration data and the preemption mode the profile doesn't set are taken from the scheduler options.
+func configParamsFromOpts(opts options.Scheduler, prof options.SchedulerProfile) (manifests.ConfigParams, error) {
+	params := manifests.ConfigParams{
+		ProfileName: prof.Name,
+		Cache:       manifests.NewConfigCacheParams(),
+	}
+
+	cacheParamsConfigData := prof.CacheParamsConfigData
+	if cacheParamsConfigData == "" {
+		cacheParamsConfigData = opts.CacheParamsConfigData
+	}
+	if len(cacheParamsConfigData) > 0 {
+		err := yaml.Unmarshal([]byte(cacheParamsConfigData), params.Cache)
+		if err != nil {
+			return params, err
+		}
+	}
+
+	// always override
+	params.Cache.ResyncPeriodSeconds = newInt64(int64(opts.CacheResyncPeriod.Seconds()))
+	if opts.InformerMode != "" {
+		if err := manifests.ValidateCacheInformerMode(opts.InformerMode); err != nil {
+			return params, err
+		}
+		params.Cache.InformerMode = newString(opts.InformerMode)
+	}
+
+	scoringStratConfigData := prof.ScoringStratConfigData
+	if scoringStratConfigData == "" {
+		scoringStratConfigData = opts.ScoringStratConfigData
+	}
+	if len(scoringStratConfigData) > 0 {
+		params.ScoringStrategy = &manifests.ScoringStrategyParams{}
+		err := yaml.Unmarshal([]byte(scoringStratConfigData), params.ScoringStrategy)
+		if err != nil {
+			return params, err
+		}
+	}
+
+	preemptionMode := prof.PreemptionMode
+	if preemptionMode == "" {
+		preemptionMode = opts.PreemptionMode
+	}
+	if preemptionMode != "" {
+		if err := manifests.ValidatePreemptionMode(preemptionMode); err != nil {
+			return params, err
+		}
+		params.PreemptionMode = newString(preemptionMode)
+	}
+
+	plugins := prof.Plugins
+	if len(plugins) == 0 {
+		plugins = opts.Plugins
+	}
+	for _, plugin := range plugins {
+		plParams, err := configPluginParamsFromOpts(plugin)
+		if err != nil {
+			return params, err
+		}
+		params.Plugins = append(params.Plugins, plParams)
+	}
+	return params, nil
+}
+
+func configPluginParamsFromOpts(plugin options.SchedulerPlugin) (manifests.ConfigPluginParams, error) {
+	plParams := manifests.ConfigPluginParams{
+		Name:       plugin.Name,
+		EnabledAt:  plugin.EnabledAt,
+		DisabledAt: plugin.DisabledAt,
+	}
+	if plugin.Weight != 0 {
+		weight := plugin.Weight
+		plParams.Weight = &weight
+	}
+	if len(plugin.ArgsData) > 0 {
+		args, err := yaml.YAMLToJSON([]byte(plugin.ArgsData))
+		if err != nil {
+			return plParams, fmt.Errorf("plugin %q: cannot decode args: %w", plugin.Name, err)
+		}
+		plParams.Args = args
+	}
+	return plParams, nil
+}
+
 func leaderElectionParamsFromOpts(opts options.Scheduler) (manifests.LeaderElectionParams, bool, error) {
 	leap := manifests.LeaderElectionParams{}
 	if !opts.LeaderElection {
@@ -337,6 +426,10 @@ func newInt64(value int64) *int64 {
 	return &value
 }
 
+func newString(value string) *string {
+	return &value
+}
+
 func toJSON(v any) string {
 	data, err := json.Marshal(v)
 	if err != nil {
@@ -22,9 +22,13 @@ import (
 
 	"github.com/go-logr/logr/testr"
 
+	corev1 "k8s.io/api/core/v1"
+
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/schedconfig"
 )
 
 func TestClone(t *testing.T) {
@@ -197,3 +201,301 @@ func Test_leaderElectionParamsFromOpts(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderProfiles(t *testing.T) {
+	mf, err := NewWithOptions(options.Render{
+		Platform: platform.Kubernetes,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	opts := options.Scheduler{
+		Replicas:               1,
+		ProfileName:            "tas-latency",
+		CacheResyncPeriod:      DefaultResyncPeriod,
+		ScoringStratConfigData: "type: LeastAllocated\n",
+		CacheParamsConfigData:  "informerMode: Dedicated\n",
+		Profiles: []options.SchedulerProfile{
+			{
+				Name:                   "tas-batch",
+				ScoringStratConfigData: "type: MostAllocated\n",
+				PreemptionMode:         manifests.PreemptionDisabled,
+			},
+		},
+	}
+	uMf, err := mf.Render(testr.New(t), opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	profs, err := manifests.DecodeSchedulerProfilesFromData([]byte(uMf.ConfigMap.Data[manifests.SchedulerConfigFileName]))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(profs) != 2 {
+		t.Fatalf("unexpected profiles: %v", profs)
+	}
+
+	latency := manifests.FindSchedulerProfileByName(profs, "tas-latency")
+	if latency == nil || latency.ScoringStrategy == nil || latency.ScoringStrategy.Type != manifests.ScoringStrategyLeastAllocated || latency.PreemptionMode != nil {
+		t.Errorf("unexpected primary profile: %+v", latency)
+	}
+	batch := manifests.FindSchedulerProfileByName(profs, "tas-batch")
+	if batch == nil || batch.ScoringStrategy == nil || batch.ScoringStrategy.Type != manifests.ScoringStrategyMostAllocated {
+		t.Fatalf("unexpected additional profile: %+v", batch)
+	}
+	if batch.PreemptionMode == nil || *batch.PreemptionMode != manifests.PreemptionDisabled {
+		t.Errorf("unexpected preemption mode: %v", batch.PreemptionMode)
+	}
+	// inherited from the scheduler-wide configuration
+	if batch.Cache.InformerMode == nil || *batch.Cache.InformerMode != manifests.CacheInformerDedicated {
+		t.Errorf("unexpected informer mode: %v", batch.Cache.InformerMode)
+	}
+
+	opts.Profiles = append(opts.Profiles, options.SchedulerProfile{Name: "tas-latency"})
+	if _, err := mf.Render(testr.New(t), opts); err == nil {
+		t.Errorf("unexpected success with duplicate profiles")
+	}
+
+	opts.Profiles = []options.SchedulerProfile{{Name: "tas-batch", PreemptionMode: "Sometimes"}}
+	if _, err := mf.Render(testr.New(t), opts); err == nil {
+		t.Errorf("unexpected success with invalid preemption mode")
+	}
+}
+
+func TestRenderPodSettingsWithCtrlPlaneAffinity(t *testing.T) {
+	mf, err := NewWithOptions(options.Render{
+		Platform: platform.Kubernetes,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	infraReq := corev1.NodeSelectorRequirement{Key: "node-role.kubernetes.io/infra", Operator: corev1.NodeSelectorOpExists}
+	ctrlPlaneReq := corev1.NodeSelectorRequirement{Key: objectupdate.NodeRoleControlPlane, Operator: corev1.NodeSelectorOpExists}
+	preferred := corev1.PreferredSchedulingTerm{
+		Weight: 10,
+		Preference: corev1.NodeSelectorTerm{
+			MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}}},
+		},
+	}
+	uMf, err := mf.Render(testr.New(t), options.Scheduler{
+		Replicas:          1,
+		CtrlPlaneAffinity: true,
+		SchedulerPod: options.PodSettings{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{infraReq}}},
+				},
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{preferred},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{ctrlPlaneReq, infraReq}}},
+		},
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{preferred},
+	}
+	if got := uMf.DPScheduler.Spec.Template.Spec.Affinity.NodeAffinity; !reflect.DeepEqual(got, expected) {
+		t.Errorf("scheduler node affinity got %+v expected %+v", got, expected)
+	}
+
+	expectedCtrl := &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{ctrlPlaneReq}}},
+		},
+	}
+	if got := uMf.DPController.Spec.Template.Spec.Affinity.NodeAffinity; !reflect.DeepEqual(got, expectedCtrl) {
+		t.Errorf("controller node affinity got %+v expected %+v", got, expectedCtrl)
+	}
+}
+
+func TestRenderPreemptionAndInformerModes(t *testing.T) {
+	type testCase struct {
+		name               string
+		opts               options.Scheduler
+		expectedPreemption map[string]string
+		expectedInformer   map[string]string
+		expectError        bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "unset",
+			opts: options.Scheduler{
+				ProfileName: "tas",
+			},
+			expectedPreemption: map[string]string{"tas": ""},
+			expectedInformer:   map[string]string{"tas": ""},
+		},
+		{
+			name: "set",
+			opts: options.Scheduler{
+				ProfileName:    "tas",
+				PreemptionMode: manifests.PreemptionEnabled,
+				InformerMode:   manifests.CacheInformerShared,
+			},
+			expectedPreemption: map[string]string{"tas": manifests.PreemptionEnabled},
+			expectedInformer:   map[string]string{"tas": manifests.CacheInformerShared},
+		},
+		{
+			name: "informer mode overrides cache params",
+			opts: options.Scheduler{
+				ProfileName:           "tas",
+				InformerMode:          manifests.CacheInformerShared,
+				CacheParamsConfigData: "informerMode: Dedicated\n",
+			},
+			expectedPreemption: map[string]string{"tas": ""},
+			expectedInformer:   map[string]string{"tas": manifests.CacheInformerShared},
+		},
+		{
+			name: "profiles inherit unless set",
+			opts: options.Scheduler{
+				ProfileName:    "tas",
+				PreemptionMode: manifests.PreemptionEnabled,
+				InformerMode:   manifests.CacheInformerDedicated,
+				Profiles: []options.SchedulerProfile{
+					{Name: "tas-inherit"},
+					{Name: "tas-own", PreemptionMode: manifests.PreemptionDisabled},
+				},
+			},
+			expectedPreemption: map[string]string{
+				"tas":         manifests.PreemptionEnabled,
+				"tas-inherit": manifests.PreemptionEnabled,
+				"tas-own":     manifests.PreemptionDisabled,
+			},
+			expectedInformer: map[string]string{
+				"tas":         manifests.CacheInformerDedicated,
+				"tas-inherit": manifests.CacheInformerDedicated,
+				"tas-own":     manifests.CacheInformerDedicated,
+			},
+		},
+		{
+			name: "invalid preemption mode",
+			opts: options.Scheduler{
+				PreemptionMode: "Sometimes",
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid informer mode",
+			opts: options.Scheduler{
+				InformerMode: "Exclusive",
+			},
+			expectError: true,
+		},
+	}
+
+	mf, err := NewWithOptions(options.Render{
+		Platform: platform.Kubernetes,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.opts.Replicas = 1
+			uMf, err := mf.Render(testr.New(t), tc.opts)
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("unexpected success")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			profs, err := manifests.DecodeSchedulerProfilesFromData([]byte(uMf.ConfigMap.Data[manifests.SchedulerConfigFileName]))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(profs) != len(tc.expectedPreemption) {
+				t.Fatalf("unexpected profiles: %v", profs)
+			}
+			for _, prof := range profs {
+				if got := stringOrEmpty(prof.PreemptionMode); got != tc.expectedPreemption[prof.ProfileName] {
+					t.Errorf("profile %q: preemption mode %q expected %q", prof.ProfileName, got, tc.expectedPreemption[prof.ProfileName])
+				}
+				if got := stringOrEmpty(prof.Cache.InformerMode); got != tc.expectedInformer[prof.ProfileName] {
+					t.Errorf("profile %q: informer mode %q expected %q", prof.ProfileName, got, tc.expectedInformer[prof.ProfileName])
+				}
+			}
+		})
+	}
+}
+
+func TestRenderPlugins(t *testing.T) {
+	mf, err := NewWithOptions(options.Render{
+		Platform: platform.Kubernetes,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	opts := options.Scheduler{
+		Replicas:    1,
+		ProfileName: "tas",
+		Plugins: []options.SchedulerPlugin{
+			{
+				Name:      "Coscheduling",
+				EnabledAt: []string{"queueSort", "permit"},
+				ArgsData:  "permitWaitingTimeSeconds: 10\n",
+			},
+		},
+		Profiles: []options.SchedulerProfile{
+			{Name: "tas-inherit"},
+			{
+				Name: "tas-own",
+				Plugins: []options.SchedulerPlugin{
+					{Name: "NodeResourcesFit", DisabledAt: []string{"score"}},
+				},
+			},
+		},
+	}
+	uMf, err := mf.Render(testr.New(t), opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg, err := schedconfig.Decode([]byte(uMf.ConfigMap.Data[manifests.SchedulerConfigFileName]))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range []string{"tas", "tas-inherit"} {
+		prof := cfg.Profile(name)
+		if prof == nil {
+			t.Fatalf("missing profile %q", name)
+		}
+		if args, ok := prof.PluginArgs("Coscheduling"); !ok || string(args) != `{"permitWaitingTimeSeconds":10}` {
+			t.Errorf("profile %q: unexpected Coscheduling args: %q", name, string(args))
+		}
+		if prof.Plugins.QueueSort == nil || prof.Plugins.Permit == nil {
+			t.Errorf("profile %q: Coscheduling not enabled: %+v", name, prof.Plugins)
+		}
+	}
+	prof := cfg.Profile("tas-own")
+	if prof == nil {
+		t.Fatalf("missing profile %q", "tas-own")
+	}
+	if _, ok := prof.PluginArgs("Coscheduling"); ok || prof.Plugins.QueueSort != nil {
+		t.Errorf("profile %q: unexpected Coscheduling configuration", "tas-own")
+	}
+	if prof.Plugins.Score == nil || len(prof.Plugins.Score.Disabled) != 1 || prof.Plugins.Score.Disabled[0].Name != "NodeResourcesFit" {
+		t.Errorf("profile %q: NodeResourcesFit not disabled: %+v", "tas-own", prof.Plugins.Score)
+	}
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -17,6 +17,7 @@
 package manifests
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -172,6 +173,18 @@ func SetDefaultsLeaderElection(lep *LeaderElectionParams) {
 	}
 }
 
+// ConfigPluginParams enables or disables a plugin of the scheduler profile at the given extension points
+// (e.g. "queueSort", "score"), and sets its arguments.
+type ConfigPluginParams struct {
+	Name       string   `json:"name"`
+	EnabledAt  []string `json:"enabledAt,omitempty"`
+	DisabledAt []string `json:"disabledAt,omitempty"`
+	// Weight is used where the plugin is enabled at the score or multiPoint extension points.
+	Weight *int32 `json:"weight,omitempty"`
+	// Args is the pluginConfig entry of the plugin, in JSON. Empty leaves the existing one, if any, untouched.
+	Args json.RawMessage `json:"args,omitempty"`
+}
+
 type ConfigParams struct {
 	// can't be empty, so no need for pointer
 	ProfileName     string                 `json:"profileName"`
@@ -179,79 +192,92 @@ type ConfigParams struct {
 	ScoringStrategy *ScoringStrategyParams `json:"scoringStrategy,omitempty"`
 	LeaderElection  *LeaderElectionParams  `json:"leaderElection"`
 	PreemptionMode  *string                `json:"preemptionMode,omitempty"`
+	// APIVersion is the apiVersion to convert the configuration to. Like LeaderElection, is a global setting.
+	// Used only when rendering, empty means keep the current apiVersion.
+	APIVersion string `json:"-"`
+	// Plugins are applied in order, after the NodeResourceTopologyMatch settings. Used only when rendering.
+	Plugins []ConfigPluginParams `json:"plugins,omitempty"`
 }
 
+// DecodeSchedulerProfilesFromData extracts the NodeResourceTopologyMatch parameters from the scheduler
+// configuration data. Malformed data is rejected; the profiles and the plugin configurations lacking
+// the fields needed to identify them are skipped. Use the schedconfig package to decode strictly,
+// rejecting unknown fields too, e.g. schedconfig.Decode(data) followed by ProfileParams().
 func DecodeSchedulerProfilesFromData(data []byte) ([]ConfigParams, error) {
 	params := []ConfigParams{}
 
 	var r unstructured.Unstructured
 	if err := yaml.Unmarshal(data, &r.Object); err != nil {
-		klog.ErrorS(err, "cannot unmarshal scheduler config")
-		return params, nil
+		return params, fmt.Errorf("cannot unmarshal scheduler config: %w", err)
 	}
 
 	lead, ok, err := unstructured.NestedMap(r.Object, "leaderElection")
 	if err != nil {
-		klog.ErrorS(err, "failed to process unstructured data")
-		return params, err
+		return params, fmt.Errorf("cannot process field leaderElection: %w", err)
 	}
 	var electParams *LeaderElectionParams
 	if ok {
 		electParams, err = extractLeaderElectionParams(lead)
 		if err != nil {
-			klog.ErrorS(err, "failed to extract leader election params")
-			return params, nil
+			return params, fmt.Errorf("cannot extract leader election params: %w", err)
 		}
 	}
 
 	profiles, ok, err := unstructured.NestedSlice(r.Object, "profiles")
-	if !ok || err != nil {
-		klog.ErrorS(err, "failed to process unstructured data", "profiles", ok)
+	if err != nil {
+		return params, fmt.Errorf("cannot process field profiles: %w", err)
+	}
+	if !ok {
+		klog.V(1).InfoS("no profiles in scheduler config")
 		return params, nil
 	}
-	for _, prof := range profiles {
+	for idx, prof := range profiles {
 		profile, ok := prof.(map[string]interface{})
 		if !ok {
-			klog.V(1).InfoS("unexpected profile data")
-			return params, nil
+			return params, fmt.Errorf("unexpected data in profile #%d", idx)
 		}
 
 		profileName, ok, err := unstructured.NestedString(profile, "schedulerName")
-		if !ok || err != nil {
-			klog.ErrorS(err, "failed to get profile name", "profileName", ok)
-			return params, nil
+		if err != nil {
+			return params, fmt.Errorf("cannot process field schedulerName of profile #%d: %w", idx, err)
+		}
+		if !ok {
+			klog.V(1).InfoS("skipped profile without name", "profile", idx)
+			continue
 		}
 
 		pluginConfigs, ok, err := unstructured.NestedSlice(profile, "pluginConfig")
-		if !ok || err != nil {
-			klog.ErrorS(err, "failed to process unstructured data", "pluginConfig", ok)
-			return params, nil
+		if err != nil {
+			return params, fmt.Errorf("cannot process field pluginConfig of profile %q: %w", profileName, err)
+		}
+		if !ok {
+			klog.V(1).InfoS("skipped profile without plugin config", "profile", profileName)
+			continue
 		}
 		for _, plConf := range pluginConfigs {
 			pluginConf, ok := plConf.(map[string]interface{})
 			if !ok {
-				klog.V(1).InfoS("unexpected profile config data")
-				return params, nil
+				return params, fmt.Errorf("unexpected plugin config data in profile %q", profileName)
 			}
 
 			name, ok, err := unstructured.NestedString(pluginConf, "name")
-			if !ok || err != nil {
-				klog.ErrorS(err, "failed to process unstructured data", "name", ok)
-				return params, nil
+			if err != nil {
+				return params, fmt.Errorf("cannot process field name of plugin config in profile %q: %w", profileName, err)
 			}
-			if name != SchedulerPluginName {
+			if !ok || name != SchedulerPluginName {
 				continue
 			}
 			args, ok, err := unstructured.NestedMap(pluginConf, "args")
-			if !ok || err != nil {
-				klog.ErrorS(err, "failed to process unstructured data", "args", ok)
-				return params, nil
+			if err != nil {
+				return params, fmt.Errorf("cannot process field args of plugin %q in profile %q: %w", name, profileName, err)
+			}
+			if !ok {
+				args = map[string]interface{}{}
 			}
 
 			profileParams, err := extractParams(profileName, args)
 			if err != nil {
-				klog.ErrorS(err, "failed to extract params", "name", name, "profile", profileName)
-				continue
+				return params, fmt.Errorf("cannot extract params of plugin %q in profile %q: %w", name, profileName, err)
 			}
 			// since Leader Election Params is a global setting (independent from profiles),
 			// all profiles must share the same data. This is a modelization error which
@@ -680,6 +680,50 @@ profiles:
 	}
 }
 
+func TestDecodeSchedulerConfigFromDataMalformed(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "not yaml",
+			data: "profiles: [",
+		},
+		{
+			name: "profiles not a list",
+			data: "profiles: foo\n",
+		},
+		{
+			name: "profile not an object",
+			data: "profiles:\n- foo\n",
+		},
+		{
+			name: "leader election not an object",
+			data: "leaderElection: foo\n",
+		},
+		{
+			name: "bad cache resync method",
+			data: `profiles:
+- schedulerName: topology-aware-scheduler
+  pluginConfig:
+  - name: NodeResourceTopologyMatch
+    args:
+      cache:
+        resyncMethod: Sometimes
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			params, err := DecodeSchedulerProfilesFromData([]byte(tc.data))
+			if err == nil {
+				t.Fatalf("expected error, got params %s", toJSON(params))
+			}
+		})
+	}
+}
+
 func toJSON(v any) string {
 	data, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
@@ -0,0 +1,141 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package manifests
+
+import (
+	"fmt"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+)
+
+const (
+	SchedulerConfigAPIVersionV1beta3 = "kubescheduler.config.k8s.io/v1beta3"
+	SchedulerConfigAPIVersionV1      = "kubescheduler.config.k8s.io/v1"
+)
+
+const (
+	SchedulerConfigVersionAuto    = "auto"
+	SchedulerConfigVersionV1beta3 = "v1beta3"
+	SchedulerConfigVersionV1      = "v1"
+)
+
+// schedulerConfigSupport tells the platform versions supporting an API version of the scheduler configuration.
+// Empty bounds are open.
+type schedulerConfigSupport struct {
+	since string
+	until string // excluded
+}
+
+var schedulerConfigSupportMatrix = map[platform.Platform]map[string]schedulerConfigSupport{
+	platform.Kubernetes: {
+		SchedulerConfigAPIVersionV1beta3: {until: "1.29"},
+		SchedulerConfigAPIVersionV1:      {since: "1.25"},
+	},
+	platform.OpenShift: {
+		SchedulerConfigAPIVersionV1beta3: {until: "4.16"},
+		SchedulerConfigAPIVersionV1:      {since: "4.12"},
+	},
+	platform.HyperShift: {
+		SchedulerConfigAPIVersionV1beta3: {until: "4.16"},
+		SchedulerConfigAPIVersionV1:      {since: "4.12"},
+	},
+}
+
+func ValidateSchedulerConfigVersion(value string) error {
+	switch value {
+	case SchedulerConfigVersionAuto:
+		return nil
+	case SchedulerConfigVersionV1beta3:
+		return nil
+	case SchedulerConfigVersionV1:
+		return nil
+	default:
+		return fmt.Errorf("unsupported scheduler config version: %v", value)
+	}
+}
+
+// SchedulerConfigAPIVersion returns the apiVersion of the scheduler configuration to render given the
+// requested `version` (one of the SchedulerConfigVersion* constants; empty means auto) and the platform.
+// In auto mode, picks the most recent API version the platform supports. Returns empty string if the
+// platform version is unknown, meaning the configuration should be left as it is.
+// Fails if the requested version is known to be unsupported by the platform.
+func SchedulerConfigAPIVersion(version string, plat platform.Platform, ver platform.Version) (string, error) {
+	if version == "" {
+		version = SchedulerConfigVersionAuto
+	}
+	if err := ValidateSchedulerConfigVersion(version); err != nil {
+		return "", err
+	}
+
+	if version != SchedulerConfigVersionAuto {
+		apiVersion := SchedulerConfigAPIVersionV1beta3
+		if version == SchedulerConfigVersionV1 {
+			apiVersion = SchedulerConfigAPIVersionV1
+		}
+		ok, known, err := isSchedulerConfigSupported(apiVersion, plat, ver)
+		if err != nil {
+			return "", err
+		}
+		if known && !ok {
+			return "", fmt.Errorf("scheduler config %s is not supported on %s %s", apiVersion, plat, ver)
+		}
+		return apiVersion, nil
+	}
+
+	// keep it ordered from most recent supported to the oldest supported
+	for _, apiVersion := range []string{SchedulerConfigAPIVersionV1, SchedulerConfigAPIVersionV1beta3} {
+		ok, known, err := isSchedulerConfigSupported(apiVersion, plat, ver)
+		if err != nil {
+			return "", err
+		}
+		if !known {
+			return "", nil
+		}
+		if ok {
+			return apiVersion, nil
+		}
+	}
+	return "", fmt.Errorf("no supported scheduler config version on %s %s", plat, ver)
+}
+
+// isSchedulerConfigSupported tells if `apiVersion` is supported on the given platform.
+// The second return value is false if the support cannot be determined.
+func isSchedulerConfigSupported(apiVersion string, plat platform.Platform, ver platform.Version) (bool, bool, error) {
+	support, ok := schedulerConfigSupportMatrix[plat][apiVersion]
+	if !ok || ver == platform.MissingVersion {
+		return false, false, nil
+	}
+	if support.since != "" {
+		ok, err := ver.AtLeastString(support.since)
+		if err != nil {
+			return false, false, err
+		}
+		if !ok {
+			return false, true, nil
+		}
+	}
+	if support.until != "" {
+		ok, err := ver.AtLeastString(support.until)
+		if err != nil {
+			return false, false, err
+		}
+		if ok {
+			return false, true, nil
+		}
+	}
+	return true, true, nil
+}
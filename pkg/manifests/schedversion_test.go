@@ -0,0 +1,129 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package manifests
+
+import (
+	"testing"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+)
+
+func TestSchedulerConfigAPIVersion(t *testing.T) {
+	type testCase struct {
+		name        string
+		version     string
+		plat        platform.Platform
+		ver         platform.Version
+		expected    string
+		expectError bool
+	}
+
+	testCases := []testCase{
+		{
+			name:     "auto, missing version",
+			plat:     platform.Kubernetes,
+			ver:      platform.MissingVersion,
+			expected: "",
+		},
+		{
+			name:     "auto, unknown platform",
+			version:  SchedulerConfigVersionAuto,
+			plat:     platform.Unknown,
+			ver:      "v1.28",
+			expected: "",
+		},
+		{
+			name:     "auto, old kubernetes",
+			version:  SchedulerConfigVersionAuto,
+			plat:     platform.Kubernetes,
+			ver:      "v1.24",
+			expected: SchedulerConfigAPIVersionV1beta3,
+		},
+		{
+			name:     "auto, recent kubernetes",
+			version:  SchedulerConfigVersionAuto,
+			plat:     platform.Kubernetes,
+			ver:      "v1.25",
+			expected: SchedulerConfigAPIVersionV1,
+		},
+		{
+			name:     "auto, old openshift",
+			plat:     platform.OpenShift,
+			ver:      "v4.11",
+			expected: SchedulerConfigAPIVersionV1beta3,
+		},
+		{
+			name:     "auto, recent hypershift",
+			plat:     platform.HyperShift,
+			ver:      "v4.18",
+			expected: SchedulerConfigAPIVersionV1,
+		},
+		{
+			name:     "explicit v1beta3, supported",
+			version:  SchedulerConfigVersionV1beta3,
+			plat:     platform.Kubernetes,
+			ver:      "v1.28",
+			expected: SchedulerConfigAPIVersionV1beta3,
+		},
+		{
+			name:        "explicit v1beta3, removed",
+			version:     SchedulerConfigVersionV1beta3,
+			plat:        platform.Kubernetes,
+			ver:         "v1.29",
+			expectError: true,
+		},
+		{
+			name:        "explicit v1, too old",
+			version:     SchedulerConfigVersionV1,
+			plat:        platform.OpenShift,
+			ver:         "v4.11",
+			expectError: true,
+		},
+		{
+			name:     "explicit v1, missing version",
+			version:  SchedulerConfigVersionV1,
+			plat:     platform.OpenShift,
+			ver:      platform.MissingVersion,
+			expected: SchedulerConfigAPIVersionV1,
+		},
+		{
+			name:        "unsupported version",
+			version:     "v1beta2",
+			plat:        platform.Kubernetes,
+			ver:         "v1.24",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := SchedulerConfigAPIVersion(tc.version, tc.plat, tc.ver)
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("unexpected success, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("got %q expected %q", got, tc.expected)
+			}
+		})
+	}
+}
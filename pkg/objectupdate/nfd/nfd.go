@@ -59,4 +59,7 @@ func UpdaterDaemonSet(ds *appsv1.DaemonSet, opts options.DaemonSet) {
 	if opts.NodeSelector != nil {
 		ds.Spec.Template.Spec.NodeSelector = opts.NodeSelector.MatchLabels
 	}
+
+	cnt := objectupdate.FindContainerByName(ds.Spec.Template.Spec.Containers, manifests.ContainerNameNFDTopologyUpdater)
+	objectupdate.SetPodSettings(&ds.Spec.Template.Spec, cnt, opts.Pod)
 }
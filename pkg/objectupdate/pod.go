@@ -0,0 +1,134 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package objectupdate
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+)
+
+// SetPodSettings applies the settings to the pod spec. The resources are applied to the
+// container `cnt`, which is expected to belong to the pod, and are skipped if it is nil.
+func SetPodSettings(podSpec *corev1.PodSpec, cnt *corev1.Container, settings options.PodSettings) {
+	if podSpec == nil {
+		return
+	}
+
+	if cnt != nil && settings.Resources != nil {
+		cnt.Resources.Requests = mergeResourceList(cnt.Resources.Requests, settings.Resources.Requests)
+		cnt.Resources.Limits = mergeResourceList(cnt.Resources.Limits, settings.Resources.Limits)
+	}
+	if settings.PriorityClassName != "" {
+		podSpec.PriorityClassName = settings.PriorityClassName
+		// the priority is resolved from the class at admission
+		podSpec.Priority = nil
+	}
+	for _, toleration := range settings.Tolerations {
+		if !hasToleration(podSpec.Tolerations, toleration) {
+			podSpec.Tolerations = append(podSpec.Tolerations, toleration)
+		}
+	}
+	if settings.NodeAffinity != nil {
+		ensureAffinity(podSpec)
+		podSpec.Affinity.NodeAffinity = mergeNodeAffinity(podSpec.Affinity.NodeAffinity, settings.NodeAffinity)
+	}
+	if settings.PodAntiAffinity != nil {
+		ensureAffinity(podSpec)
+		podSpec.Affinity.PodAntiAffinity = settings.PodAntiAffinity.DeepCopy()
+	}
+	if len(settings.TopologySpreadConstraints) > 0 {
+		podSpec.TopologySpreadConstraints = nil
+		for idx := range settings.TopologySpreadConstraints {
+			podSpec.TopologySpreadConstraints = append(podSpec.TopologySpreadConstraints, *settings.TopologySpreadConstraints[idx].DeepCopy())
+		}
+	}
+}
+
+// mergeNodeAffinity returns the node affinity requiring both `current` and `updates`, preferring
+// the nodes either prefers. This way the rendered placement constraints, like the control plane
+// affinity, are kept.
+func mergeNodeAffinity(current, updates *corev1.NodeAffinity) *corev1.NodeAffinity {
+	if current == nil {
+		return updates.DeepCopy()
+	}
+	ret := current.DeepCopy()
+	ret.RequiredDuringSchedulingIgnoredDuringExecution = mergeNodeSelector(ret.RequiredDuringSchedulingIgnoredDuringExecution, updates.RequiredDuringSchedulingIgnoredDuringExecution)
+	for idx := range updates.PreferredDuringSchedulingIgnoredDuringExecution {
+		ret.PreferredDuringSchedulingIgnoredDuringExecution = append(ret.PreferredDuringSchedulingIgnoredDuringExecution, *updates.PreferredDuringSchedulingIgnoredDuringExecution[idx].DeepCopy())
+	}
+	return ret
+}
+
+// mergeNodeSelector returns the node selector matching the nodes both `current` and `updates` match.
+// The terms are ORed, so each term of the result combines a term of `current` with a term of `updates`.
+func mergeNodeSelector(current, updates *corev1.NodeSelector) *corev1.NodeSelector {
+	if updates == nil || len(updates.NodeSelectorTerms) == 0 {
+		return current
+	}
+	if current == nil || len(current.NodeSelectorTerms) == 0 {
+		return updates.DeepCopy()
+	}
+	ret := &corev1.NodeSelector{}
+	for _, cur := range current.NodeSelectorTerms {
+		for _, upd := range updates.NodeSelectorTerms {
+			term := corev1.NodeSelectorTerm{}
+			for _, req := range cur.MatchExpressions {
+				term.MatchExpressions = append(term.MatchExpressions, *req.DeepCopy())
+			}
+			for _, req := range upd.MatchExpressions {
+				term.MatchExpressions = append(term.MatchExpressions, *req.DeepCopy())
+			}
+			for _, req := range cur.MatchFields {
+				term.MatchFields = append(term.MatchFields, *req.DeepCopy())
+			}
+			for _, req := range upd.MatchFields {
+				term.MatchFields = append(term.MatchFields, *req.DeepCopy())
+			}
+			ret.NodeSelectorTerms = append(ret.NodeSelectorTerms, term)
+		}
+	}
+	return ret
+}
+
+func mergeResourceList(current, updates corev1.ResourceList) corev1.ResourceList {
+	if len(updates) == 0 {
+		return current
+	}
+	if current == nil {
+		current = corev1.ResourceList{}
+	}
+	for name, qty := range updates {
+		current[name] = qty.DeepCopy()
+	}
+	return current
+}
+
+func hasToleration(tolerations []corev1.Toleration, toleration corev1.Toleration) bool {
+	for idx := range tolerations {
+		if tolerations[idx].MatchToleration(&toleration) {
+			return true
+		}
+	}
+	return false
+}
+
+func ensureAffinity(podSpec *corev1.PodSpec) {
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+}
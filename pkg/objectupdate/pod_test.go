@@ -0,0 +1,202 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package objectupdate
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+)
+
+func TestSetPodSettings(t *testing.T) {
+	nodeAffinity := &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{
+					MatchExpressions: []corev1.NodeSelectorRequirement{
+						{
+							Key:      "node-role.kubernetes.io/infra",
+							Operator: corev1.NodeSelectorOpExists,
+						},
+					},
+				},
+			},
+		},
+	}
+	podAntiAffinity := &corev1.PodAntiAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+			{
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "secondary-scheduler"},
+				},
+				TopologyKey: "kubernetes.io/hostname",
+			},
+		},
+	}
+	spread := corev1.TopologySpreadConstraint{
+		MaxSkew:           1,
+		TopologyKey:       "topology.kubernetes.io/zone",
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+	}
+	infraToleration := corev1.Toleration{
+		Key:      "node-role.kubernetes.io/infra",
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+
+	type testCase struct {
+		name            string
+		podSpec         corev1.PodSpec
+		settings        options.PodSettings
+		expectedPodSpec corev1.PodSpec
+	}
+
+	testCases := []testCase{
+		{
+			name:            "empty settings",
+			podSpec:         podSpecWithResources(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")}, nil),
+			expectedPodSpec: podSpecWithResources(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")}, nil),
+		},
+		{
+			name:    "resources are merged",
+			podSpec: podSpecWithResources(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m"), corev1.ResourceMemory: resource.MustParse("64Mi")}, nil),
+			settings: options.PodSettings{
+				Resources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+				},
+			},
+			expectedPodSpec: podSpecWithResources(
+				corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m"), corev1.ResourceMemory: resource.MustParse("64Mi")},
+				corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+			),
+		},
+		{
+			name: "placement",
+			podSpec: corev1.PodSpec{
+				PriorityClassName: "system-node-critical",
+				Tolerations:       []corev1.Toleration{infraToleration},
+				Affinity: &corev1.Affinity{
+					PodAffinity: &corev1.PodAffinity{},
+				},
+			},
+			settings: options.PodSettings{
+				PriorityClassName: "infra-critical",
+				Tolerations: []corev1.Toleration{
+					infraToleration,
+					{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpEqual,
+						Value:    "tas",
+						Effect:   corev1.TaintEffectNoExecute,
+					},
+				},
+				NodeAffinity:              nodeAffinity,
+				PodAntiAffinity:           podAntiAffinity,
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{spread},
+			},
+			expectedPodSpec: corev1.PodSpec{
+				PriorityClassName: "infra-critical",
+				Tolerations: []corev1.Toleration{
+					infraToleration,
+					{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpEqual,
+						Value:    "tas",
+						Effect:   corev1.TaintEffectNoExecute,
+					},
+				},
+				Affinity: &corev1.Affinity{
+					NodeAffinity:    nodeAffinity,
+					PodAffinity:     &corev1.PodAffinity{},
+					PodAntiAffinity: podAntiAffinity,
+				},
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{spread},
+			},
+		},
+		{
+			name: "node affinity is merged",
+			podSpec: corev1.PodSpec{
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+							NodeSelectorTerms: []corev1.NodeSelectorTerm{
+								{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: NodeRoleControlPlane, Operator: corev1.NodeSelectorOpExists}}},
+								{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: NodeRoleControlPlaneDeprecated, Operator: corev1.NodeSelectorOpExists}}},
+							},
+						},
+					},
+				},
+			},
+			settings: options.PodSettings{
+				NodeAffinity: nodeAffinity,
+			},
+			expectedPodSpec: corev1.PodSpec{
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+							NodeSelectorTerms: []corev1.NodeSelectorTerm{
+								{MatchExpressions: []corev1.NodeSelectorRequirement{
+									{Key: NodeRoleControlPlane, Operator: corev1.NodeSelectorOpExists},
+									{Key: "node-role.kubernetes.io/infra", Operator: corev1.NodeSelectorOpExists},
+								}},
+								{MatchExpressions: []corev1.NodeSelectorRequirement{
+									{Key: NodeRoleControlPlaneDeprecated, Operator: corev1.NodeSelectorOpExists},
+									{Key: "node-role.kubernetes.io/infra", Operator: corev1.NodeSelectorOpExists},
+								}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.podSpec.DeepCopy()
+			var cnt *corev1.Container
+			if len(got.Containers) > 0 {
+				cnt = &got.Containers[0]
+			}
+			SetPodSettings(got, cnt, tc.settings)
+			if diff := cmp.Diff(tc.expectedPodSpec, *got); diff != "" {
+				t.Errorf("unexpected pod spec: %s", diff)
+			}
+		})
+	}
+}
+
+func podSpecWithResources(requests, limits corev1.ResourceList) corev1.PodSpec {
+	return corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name: "main",
+				Resources: corev1.ResourceRequirements{
+					Requests: requests,
+					Limits:   limits,
+				},
+			},
+		},
+	}
+}
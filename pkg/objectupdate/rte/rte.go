@@ -92,6 +92,7 @@ func DaemonSet(ds *appsv1.DaemonSet, plat platform.Platform, configMapName strin
 	}
 
 	daemonSetContainerConfig(podSpec, cntSpec, plat, configMapName, opts)
+	objectupdate.SetPodSettings(podSpec, cntSpec, opts.Pod)
 }
 
 func MetricsPort(ds *appsv1.DaemonSet, portNum int) {
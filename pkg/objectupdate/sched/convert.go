@@ -0,0 +1,86 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package sched
+
+import (
+	"fmt"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/schedconfig"
+)
+
+// removedPlugins lists the in-tree plugins which the given API version does not accept anymore.
+var removedPlugins = map[string][]string{
+	manifests.SchedulerConfigAPIVersionV1: {
+		"SelectorSpread",
+	},
+}
+
+// convertConfig converts the scheduler configuration to `apiVersion` in place.
+// The configurations we manage have the same schema across the supported versions,
+// so the conversion only needs to report what the target version would refuse to load.
+func convertConfig(cfg *schedconfig.Configuration, apiVersion string) (bool, error) {
+	if !isSupportedAPIVersion(apiVersion) {
+		return false, fmt.Errorf("unsupported scheduler config apiVersion: %q", apiVersion)
+	}
+	if !isSupportedAPIVersion(cfg.APIVersion) {
+		return false, fmt.Errorf("cannot convert scheduler config from apiVersion %q", cfg.APIVersion)
+	}
+
+	if err := checkRemovedPlugins(cfg, apiVersion); err != nil {
+		return false, err
+	}
+
+	if cfg.APIVersion == apiVersion {
+		return false, nil
+	}
+	cfg.APIVersion = apiVersion
+	return true, nil
+}
+
+func checkRemovedPlugins(cfg *schedconfig.Configuration, apiVersion string) error {
+	removed := removedPlugins[apiVersion]
+	if len(removed) == 0 {
+		return nil
+	}
+	for _, profile := range cfg.Profiles {
+		for _, name := range profilePluginNames(profile) {
+			for _, removedName := range removed {
+				if name == removedName {
+					return fmt.Errorf("profile %q: plugin %q is not supported by %s", profile.SchedulerName, name, apiVersion)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// profilePluginNames returns the names of the plugins a profile enables or configures.
+func profilePluginNames(profile schedconfig.Profile) []string {
+	var names []string
+	if profile.Plugins != nil {
+		names = append(names, profile.Plugins.PluginNames()...)
+	}
+	for _, pluginConf := range profile.PluginConfig {
+		names = append(names, pluginConf.Name)
+	}
+	return names
+}
+
+func isSupportedAPIVersion(apiVersion string) bool {
+	return apiVersion == manifests.SchedulerConfigAPIVersionV1beta3 || apiVersion == manifests.SchedulerConfigAPIVersionV1
+}
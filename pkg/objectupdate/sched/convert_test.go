@@ -0,0 +1,104 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package sched
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+)
+
+func TestRenderConfigAPIVersion(t *testing.T) {
+	type testCase struct {
+		name           string
+		initial        string
+		apiVersion     string
+		expectedPrefix string
+		expectedUpdate bool
+		expectError    bool
+	}
+
+	testCases := []testCase{
+		{
+			name:           "keep",
+			initial:        configTemplateEmpty,
+			expectedPrefix: "apiVersion: " + manifests.SchedulerConfigAPIVersionV1beta3 + "\n",
+		},
+		{
+			name:           "same version",
+			initial:        configTemplateEmpty,
+			apiVersion:     manifests.SchedulerConfigAPIVersionV1beta3,
+			expectedPrefix: "apiVersion: " + manifests.SchedulerConfigAPIVersionV1beta3 + "\n",
+		},
+		{
+			name:           "v1beta3 to v1",
+			initial:        configTemplateEmpty,
+			apiVersion:     manifests.SchedulerConfigAPIVersionV1,
+			expectedPrefix: "apiVersion: " + manifests.SchedulerConfigAPIVersionV1 + "\n",
+			expectedUpdate: true,
+		},
+		{
+			name:           "v1 to v1beta3",
+			initial:        strings.Replace(configTemplateEmpty, manifests.SchedulerConfigAPIVersionV1beta3, manifests.SchedulerConfigAPIVersionV1, 1),
+			apiVersion:     manifests.SchedulerConfigAPIVersionV1beta3,
+			expectedPrefix: "apiVersion: " + manifests.SchedulerConfigAPIVersionV1beta3 + "\n",
+			expectedUpdate: true,
+		},
+		{
+			name:        "removed plugin",
+			initial:     strings.Replace(configTemplateEmpty, "    score:\n      enabled:\n", "    score:\n      enabled:\n      - name: SelectorSpread\n", 1),
+			apiVersion:  manifests.SchedulerConfigAPIVersionV1,
+			expectError: true,
+		},
+		{
+			name:        "unsupported source version",
+			initial:     strings.Replace(configTemplateEmpty, manifests.SchedulerConfigAPIVersionV1beta3, "kubescheduler.config.k8s.io/v1beta2", 1),
+			apiVersion:  manifests.SchedulerConfigAPIVersionV1,
+			expectError: true,
+		},
+		{
+			name:        "unsupported target version",
+			initial:     configTemplateEmpty,
+			apiVersion:  "kubescheduler.config.k8s.io/v1beta2",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, ok, err := RenderConfig([]byte(tc.initial), "test-sched-name", &manifests.ConfigParams{
+				APIVersion: tc.apiVersion,
+			})
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("unexpected success:\n%s", string(data))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RenderConfig() failed: %v", err)
+			}
+			if ok != tc.expectedUpdate {
+				t.Errorf("updated %v expected update %v", ok, tc.expectedUpdate)
+			}
+			if !strings.HasPrefix(string(data), tc.expectedPrefix) {
+				t.Errorf("unexpected rendering:\n%s", string(data))
+			}
+		})
+	}
+}
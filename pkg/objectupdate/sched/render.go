@@ -21,15 +21,22 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/klog/v2"
 
-	"sigs.k8s.io/yaml"
-
 	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/schedconfig"
 )
 
 func SchedulerConfig(cm *corev1.ConfigMap, schedulerName string, params *manifests.ConfigParams) error {
+	if params == nil {
+		return SchedulerConfigProfiles(cm, schedulerName, nil)
+	}
+	return SchedulerConfigProfiles(cm, schedulerName, []manifests.ConfigParams{*params})
+}
+
+// SchedulerConfigProfiles is like SchedulerConfig, but renders a profile for each of the given params.
+// See RenderConfigProfiles for details.
+func SchedulerConfigProfiles(cm *corev1.ConfigMap, schedulerName string, profileParams []manifests.ConfigParams) error {
 	if cm.Data == nil {
 		return fmt.Errorf("no data found in ConfigMap: %s/%s", cm.Namespace, cm.Name)
 	}
@@ -39,7 +46,7 @@ func SchedulerConfig(cm *corev1.ConfigMap, schedulerName string, params *manifes
 		return fmt.Errorf("no data key named: %s found in ConfigMap: %s/%s", manifests.SchedulerConfigFileName, cm.Namespace, cm.Name)
 	}
 
-	newData, _, err := RenderConfig([]byte(data), schedulerName, params)
+	newData, _, err := RenderConfigProfiles([]byte(data), schedulerName, profileParams)
 	if err != nil {
 		return err
 	}
@@ -49,218 +56,249 @@ func SchedulerConfig(cm *corev1.ConfigMap, schedulerName string, params *manifes
 }
 
 func RenderConfig(data []byte, schedulerName string, params *manifests.ConfigParams) ([]byte, bool, error) {
-	if schedulerName == "" || params == nil {
-		klog.InfoS("missing parameters, passing through", "schedulerName", schedulerName, "params", toJSON(params))
+	if params == nil {
+		return RenderConfigProfiles(data, schedulerName, nil)
+	}
+	return RenderConfigProfiles(data, schedulerName, []manifests.ConfigParams{*params})
+}
+
+// RenderConfigProfiles renders the scheduler configuration using the profile named `schedulerName`
+// as template: the template is replaced by one profile for each of the given params, in order.
+// The other profiles are left untouched. The leader election settings are global, so they are
+// taken from the first params which set them, and so is the apiVersion to convert the configuration to.
+func RenderConfigProfiles(data []byte, schedulerName string, profileParams []manifests.ConfigParams) ([]byte, bool, error) {
+	if schedulerName == "" || len(profileParams) == 0 {
+		klog.InfoS("missing parameters, passing through", "schedulerName", schedulerName, "params", toJSON(profileParams))
 		return data, false, nil
 	}
 
-	var r unstructured.Unstructured
-	if err := yaml.Unmarshal(data, &r.Object); err != nil {
-		klog.ErrorS(err, "cannot unmarshal scheduler config, passing through")
+	if err := validateProfileNames(schedulerName, profileParams); err != nil {
 		return data, false, err
 	}
 
-	updated := false
+	cfg, err := schedconfig.Decode(data)
+	if err != nil {
+		klog.ErrorS(err, "cannot decode scheduler config")
+		return data, false, err
+	}
 
-	if params.LeaderElection != nil {
-		lead, ok, err := unstructured.NestedMap(r.Object, "leaderElection")
-		if !ok || err != nil {
-			klog.ErrorS(err, "failed to process unstructured data", "leaderElection", ok)
-			return data, false, err
-		}
+	updated := false
 
-		leadUpdated, err := updateLeaderElection(lead, params)
+	if apiVersion := findAPIVersion(profileParams); apiVersion != "" {
+		convUpdated, err := convertConfig(cfg, apiVersion)
 		if err != nil {
-			klog.ErrorS(err, "failed to update unstructured data", "leaderElection", lead, "params", params)
+			klog.ErrorS(err, "cannot convert scheduler config", "apiVersion", apiVersion)
 			return data, false, err
 		}
-		if leadUpdated {
+		if convUpdated {
 			updated = true
 		}
-
-		if err := unstructured.SetNestedMap(r.Object, lead, "leaderElection"); err != nil {
-			klog.ErrorS(err, "failed to override unstructured data", "data", "leaderElection")
-			return data, false, err
-		}
-
 	}
 
-	profiles, ok, err := unstructured.NestedSlice(r.Object, "profiles")
-	if !ok || err != nil {
-		klog.ErrorS(err, "failed to process unstructured data", "profiles", ok)
-		return data, false, err
-	}
-	for _, prof := range profiles {
-		profile, ok := prof.(map[string]interface{})
-		if !ok {
-			klog.InfoS("unexpected profile data")
-			return data, false, nil
-		}
-
-		profileName, ok, err := unstructured.NestedString(profile, "schedulerName")
-		if !ok || err != nil {
-			klog.ErrorS(err, "failed to get profile name", "profileName", ok)
-			return data, false, err
+	if params := findLeaderElectionParams(profileParams); params != nil {
+		if cfg.LeaderElection == nil {
+			cfg.LeaderElection = &schedconfig.LeaderElectionConfiguration{}
 		}
+		updateLeaderElection(cfg.LeaderElection, params)
+		updated = true
+	}
 
-		if profileName != schedulerName {
+	var newProfiles []schedconfig.Profile
+	found := false
+	for idx := range cfg.Profiles {
+		profile := &cfg.Profiles[idx]
+		if profile.SchedulerName != schedulerName {
+			newProfiles = append(newProfiles, *profile)
 			continue
 		}
 
-		if params.ProfileName != "" {
-			err = unstructured.SetNestedField(profile, params.ProfileName, "schedulerName")
-			if err != nil {
-				klog.ErrorS(err, "failed to update unstructured data", "schedulerName", params.ProfileName)
-				return data, false, err
-			}
-			updated = true
-		}
-
-		pluginConfigs, ok, err := unstructured.NestedSlice(profile, "pluginConfig")
-		if !ok || err != nil {
-			klog.ErrorS(err, "failed to process unstructured data", "pluginConfig", ok)
-			return data, false, err
+		found = true
+		if len(profileParams) > 1 {
+			updated = true // the template itself is replaced
 		}
-		for _, plConf := range pluginConfigs {
-			pluginConf, ok := plConf.(map[string]interface{})
-			if !ok {
-				klog.V(1).InfoS("unexpected profile coonfig data")
-				return data, false, nil
-			}
 
-			name, ok, err := unstructured.NestedString(pluginConf, "name")
-			if !ok || err != nil {
-				klog.ErrorS(err, "failed to process unstructured data", "name", ok)
-				return data, false, err
-			}
-			if name != manifests.SchedulerPluginName {
-				continue
-			}
-			args, ok, err := unstructured.NestedMap(pluginConf, "args")
-			if !ok || err != nil {
-				klog.ErrorS(err, "failed to process unstructured data", "args", ok)
+		for idx := range profileParams {
+			newProfile, err := profile.Clone()
+			if err != nil {
 				return data, false, err
 			}
-
-			argsUpdated, err := updateArgs(args, params)
+			profUpdated, err := updateProfile(&newProfile, &profileParams[idx])
 			if err != nil {
-				klog.ErrorS(err, "failed to update unstructured data", "args", args, "params", params)
+				klog.ErrorS(err, "failed to update profile", "profileName", schedulerName, "params", toJSON(profileParams[idx]))
 				return data, false, err
 			}
-			if argsUpdated {
+			if profUpdated {
 				updated = true
 			}
-
-			if err := unstructured.SetNestedMap(pluginConf, args, "args"); err != nil {
-				klog.ErrorS(err, "failed to override unstructured data", "data", "args")
-				return data, false, err
-			}
-		}
-
-		if err := unstructured.SetNestedSlice(profile, pluginConfigs, "pluginConfig"); err != nil {
-			klog.ErrorS(err, "failed to override unstructured data", "data", "pluginConfig")
-			return data, false, err
+			newProfiles = append(newProfiles, newProfile)
 		}
 	}
 
-	if err := unstructured.SetNestedSlice(r.Object, profiles, "profiles"); err != nil {
-		klog.ErrorS(err, "failed to override unstructured data", "data", "profiles")
-		return data, false, err
+	if !found && len(profileParams) > 1 {
+		return data, false, fmt.Errorf("cannot find the scheduler profile %q to render the profiles from", schedulerName)
 	}
+	cfg.Profiles = newProfiles
 
-	newData, err := yaml.Marshal(&r.Object)
+	newData, err := schedconfig.Encode(cfg)
 	if err != nil {
-		klog.ErrorS(err, "cannot re-encode scheduler config, passing through")
-		return data, false, nil
+		klog.ErrorS(err, "cannot encode scheduler config")
+		return data, false, err
 	}
 	return newData, updated, nil
 }
 
-func updateLeaderElection(lead map[string]interface{}, params *manifests.ConfigParams) (bool, error) {
-	var updated int
-	var err error
+// updateProfile updates the given profile in place. Returns true if it updated the profile.
+func updateProfile(profile *schedconfig.Profile, params *manifests.ConfigParams) (bool, error) {
+	updated := false
 
-	err = unstructured.SetNestedField(lead, params.LeaderElection.LeaderElect, "leaderElect")
-	if err != nil {
-		return updated > 0, err
+	if params.ProfileName != "" {
+		profile.SchedulerName = params.ProfileName
+		updated = true
 	}
-	updated++
 
-	err = unstructured.SetNestedField(lead, params.LeaderElection.ResourceName, "resourceName")
+	args, ok, err := profile.NodeResourceTopologyMatchArgs()
 	if err != nil {
-		return updated > 0, err
+		return false, err
 	}
-	updated++
+	if ok {
+		argsUpdated, err := updateArgs(args, params)
+		if err != nil {
+			return false, err
+		}
+		if argsUpdated {
+			updated = true
+		}
 
-	err = unstructured.SetNestedField(lead, params.LeaderElection.ResourceNamespace, "resourceNamespace")
-	if err != nil {
-		return updated > 0, err
+		if err := profile.SetNodeResourceTopologyMatchArgs(args); err != nil {
+			return false, err
+		}
 	}
-	updated++
-
-	return updated > 0, nil
 
+	if len(params.Plugins) > 0 {
+		if err := updatePlugins(profile, params.Plugins); err != nil {
+			return false, err
+		}
+		updated = true
+	}
+	return updated, nil
 }
 
-func updateArgs(args map[string]interface{}, params *manifests.ConfigParams) (bool, error) {
-	var updated int
-	var err error
-
-	if params.Cache != nil {
-		if params.Cache.ResyncPeriodSeconds != nil {
-			resyncPeriod := *params.Cache.ResyncPeriodSeconds // shortcut
-			err = unstructured.SetNestedField(args, resyncPeriod, "cacheResyncPeriodSeconds")
-			if err != nil {
-				return updated > 0, err
+// updatePlugins enables and disables the plugins of the profile and sets their arguments.
+// The NodeResourceTopologyMatch plugin is managed through the other params, so it can't be changed here.
+func updatePlugins(profile *schedconfig.Profile, pluginParams []manifests.ConfigPluginParams) error {
+	if profile.Plugins == nil {
+		profile.Plugins = &schedconfig.Plugins{}
+	}
+	for _, plParams := range pluginParams {
+		if plParams.Name == "" {
+			return fmt.Errorf("profile %q: missing plugin name", profile.SchedulerName)
+		}
+		if plParams.Name == manifests.SchedulerPluginName {
+			return fmt.Errorf("profile %q: cannot change the plugin %q", profile.SchedulerName, plParams.Name)
+		}
+		for _, extPoint := range plParams.DisabledAt {
+			if err := profile.Plugins.DisablePlugin(extPoint, plParams.Name); err != nil {
+				return fmt.Errorf("profile %q: plugin %q: %w", profile.SchedulerName, plParams.Name, err)
 			}
-			updated++
+		}
+		for _, extPoint := range plParams.EnabledAt {
+			plugin := schedconfig.Plugin{
+				Name: plParams.Name,
+			}
+			if extPoint == "score" || extPoint == "multiPoint" {
+				plugin.Weight = plParams.Weight
+			}
+			if err := profile.Plugins.EnablePlugin(extPoint, plugin); err != nil {
+				return fmt.Errorf("profile %q: plugin %q: %w", profile.SchedulerName, plParams.Name, err)
+			}
+		}
+		if len(plParams.Args) > 0 {
+			if !json.Valid(plParams.Args) {
+				return fmt.Errorf("profile %q: plugin %q: malformed args", profile.SchedulerName, plParams.Name)
+			}
+			profile.SetPluginArgs(plParams.Name, plParams.Args)
 		}
 	}
+	return nil
+}
 
-	cacheArgs, ok, err := unstructured.NestedMap(args, "cache")
-	if !ok {
-		cacheArgs = make(map[string]interface{})
-	}
-	if err != nil {
-		return updated > 0, err
+func validateProfileNames(schedulerName string, profileParams []manifests.ConfigParams) error {
+	seen := make(map[string]bool)
+	for _, params := range profileParams {
+		name := params.ProfileName
+		if name == "" {
+			name = schedulerName
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate scheduler profile: %q", name)
+		}
+		seen[name] = true
 	}
+	return nil
+}
 
-	var cacheArgsUpdated int
-	if params.Cache != nil {
-		cacheArgsUpdated, err = updateCacheArgs(cacheArgs, params)
-		if err != nil {
-			return updated > 0, err
+func findAPIVersion(profileParams []manifests.ConfigParams) string {
+	for _, params := range profileParams {
+		if params.APIVersion != "" {
+			return params.APIVersion
 		}
 	}
-	updated += cacheArgsUpdated
+	return ""
+}
 
-	if cacheArgsUpdated > 0 {
-		if err := unstructured.SetNestedMap(args, cacheArgs, "cache"); err != nil {
-			return updated > 0, err
+func findLeaderElectionParams(profileParams []manifests.ConfigParams) *manifests.ConfigParams {
+	for idx := range profileParams {
+		if profileParams[idx].LeaderElection != nil {
+			return &profileParams[idx]
 		}
 	}
+	return nil
+}
 
-	scoringStratArgs, ok, err := unstructured.NestedMap(args, "scoringStrategy")
-	if !ok {
-		scoringStratArgs = make(map[string]interface{})
-	}
-	if err != nil {
-		return updated > 0, err
-	}
+func updateLeaderElection(lead *schedconfig.LeaderElectionConfiguration, params *manifests.ConfigParams) {
+	leaderElect := params.LeaderElection.LeaderElect
+	lead.LeaderElect = &leaderElect
+	lead.ResourceName = params.LeaderElection.ResourceName
+	lead.ResourceNamespace = params.LeaderElection.ResourceNamespace
+}
 
-	var scoringStratArgsUpdated int
-	if params.ScoringStrategy != nil {
-		scoringStratArgsUpdated, err = updateScoringStrategyArgs(scoringStratArgs, params)
+func updateArgs(args *schedconfig.NodeResourceTopologyMatchArgs, params *manifests.ConfigParams) (bool, error) {
+	var updated int
+
+	if params.Cache != nil {
+		if params.Cache.ResyncPeriodSeconds != nil {
+			resyncPeriod := *params.Cache.ResyncPeriodSeconds // shortcut
+			args.CacheResyncPeriodSeconds = &resyncPeriod
+			updated++
+		}
+
+		cacheArgs := args.Cache
+		if cacheArgs == nil {
+			cacheArgs = &schedconfig.NodeResourceTopologyCacheArgs{}
+		}
+		cacheArgsUpdated, err := updateCacheArgs(cacheArgs, params)
 		if err != nil {
 			return updated > 0, err
 		}
+		if cacheArgsUpdated > 0 {
+			args.Cache = cacheArgs
+		}
+		updated += cacheArgsUpdated
 	}
-	updated += scoringStratArgsUpdated
 
-	if scoringStratArgsUpdated > 0 {
-		if err := unstructured.SetNestedMap(args, scoringStratArgs, "scoringStrategy"); err != nil {
+	if params.ScoringStrategy != nil {
+		scoringStratArgs := args.ScoringStrategy
+		if scoringStratArgs == nil {
+			scoringStratArgs = &schedconfig.ScoringStrategy{}
+		}
+		scoringStratArgsUpdated, err := updateScoringStrategyArgs(scoringStratArgs, params)
+		if err != nil {
 			return updated > 0, err
 		}
+		if scoringStratArgsUpdated > 0 {
+			args.ScoringStrategy = scoringStratArgs
+		}
+		updated += scoringStratArgsUpdated
 	}
 
 	preemptionArgsUpdated, err := updatePreemptionArgs(args, params)
@@ -269,127 +307,91 @@ func updateArgs(args map[string]interface{}, params *manifests.ConfigParams) (bo
 	}
 	updated += preemptionArgsUpdated
 
-	return updated > 0, ensureBackwardCompatibility(args)
+	ensureBackwardCompatibility(args)
+	return updated > 0, nil
 }
 
-func updateCacheArgs(args map[string]interface{}, params *manifests.ConfigParams) (int, error) {
+func updateCacheArgs(args *schedconfig.NodeResourceTopologyCacheArgs, params *manifests.ConfigParams) (int, error) {
 	var updated int
-	var err error
 
 	if params.Cache.ResyncMethod != nil {
 		resyncMethod := *params.Cache.ResyncMethod // shortcut
-		err = manifests.ValidateCacheResyncMethod(resyncMethod)
-		if err != nil {
-			return updated, err
-		}
-		err = unstructured.SetNestedField(args, resyncMethod, "resyncMethod")
-		if err != nil {
+		if err := manifests.ValidateCacheResyncMethod(resyncMethod); err != nil {
 			return updated, err
 		}
+		args.ResyncMethod = &resyncMethod
 		updated++
 	}
 	if params.Cache.ForeignPodsDetectMode != nil {
 		foreignPodsMode := *params.Cache.ForeignPodsDetectMode // shortcut
-		err = manifests.ValidateForeignPodsDetectMode(foreignPodsMode)
-		if err != nil {
-			return updated, err
-		}
-		err = unstructured.SetNestedField(args, foreignPodsMode, "foreignPodsDetect")
-		if err != nil {
+		if err := manifests.ValidateForeignPodsDetectMode(foreignPodsMode); err != nil {
 			return updated, err
 		}
+		args.ForeignPodsDetect = &foreignPodsMode
 		updated++
 	}
 	if params.Cache.InformerMode != nil {
 		informerMode := *params.Cache.InformerMode // shortcut
-		err = manifests.ValidateCacheInformerMode(informerMode)
-		if err != nil {
-			return updated, err
-		}
-		err = unstructured.SetNestedField(args, informerMode, "informerMode")
-		if err != nil {
+		if err := manifests.ValidateCacheInformerMode(informerMode); err != nil {
 			return updated, err
 		}
+		args.InformerMode = &informerMode
 		updated++
 	}
 
 	return updated, nil
 }
 
-func updateScoringStrategyArgs(args map[string]interface{}, params *manifests.ConfigParams) (int, error) {
+func updateScoringStrategyArgs(args *schedconfig.ScoringStrategy, params *manifests.ConfigParams) (int, error) {
 	var updated int
-	var err error
 
 	if params.ScoringStrategy.Type != "" {
 		scoringStratType := params.ScoringStrategy.Type // shortcut
-		err = manifests.ValidateScoringStrategyType(scoringStratType)
-		if err != nil {
-			return updated, err
-		}
-		err = unstructured.SetNestedField(args, scoringStratType, "type")
-		if err != nil {
+		if err := manifests.ValidateScoringStrategyType(scoringStratType); err != nil {
 			return updated, err
 		}
+		args.Type = scoringStratType
 		updated++
 	}
 
 	if len(params.ScoringStrategy.Resources) > 0 {
-		var resources []interface{}
+		var resources []schedconfig.ResourceSpec
 		for _, scRes := range params.ScoringStrategy.Resources {
-			resources = append(resources, map[string]interface{}{
-				"name":   scRes.Name,
-				"weight": scRes.Weight,
+			resources = append(resources, schedconfig.ResourceSpec{
+				Name:   scRes.Name,
+				Weight: scRes.Weight,
 			})
 		}
-		if err := unstructured.SetNestedSlice(args, resources, "resources"); err != nil {
-			return updated, err
-		}
+		args.Resources = resources
 		updated++
 	}
 
 	return updated, nil
 }
 
-func updatePreemptionArgs(args map[string]interface{}, params *manifests.ConfigParams) (int, error) {
+func updatePreemptionArgs(args *schedconfig.NodeResourceTopologyMatchArgs, params *manifests.ConfigParams) (int, error) {
 	if params.PreemptionMode == nil {
-		if _, ok, err := unstructured.NestedString(args, "preemptionMode"); err != nil {
-			return 0, err
-		} else if ok {
+		if args.PreemptionMode != nil {
 			// remove for backward compatibility
-			delete(args, "preemptionMode")
+			args.PreemptionMode = nil
 			return 1, nil
 		}
 		return 0, nil
 	}
 
 	preemptionMode := *params.PreemptionMode // shortcut
-	err := manifests.ValidatePreemptionMode(preemptionMode)
-	if err != nil {
-		return 0, err
-	}
-	err = unstructured.SetNestedField(args, preemptionMode, "preemptionMode")
-	if err != nil {
+	if err := manifests.ValidatePreemptionMode(preemptionMode); err != nil {
 		return 0, err
 	}
+	args.PreemptionMode = &preemptionMode
 	return 1, nil
 }
 
-func ensureBackwardCompatibility(args map[string]interface{}) error {
-	resyncPeriod, ok, err := unstructured.NestedInt64(args, "cacheResyncPeriodSeconds")
-	if !ok {
-		// nothing to do
-		return nil
-	}
-	if err != nil {
-		return fmt.Errorf("cannot process field cacheResyncPeriodSeconds: %w", err)
-	}
-	if resyncPeriod > 0 {
-		// nothing to do
-	} else {
+func ensureBackwardCompatibility(args *schedconfig.NodeResourceTopologyMatchArgs) {
+	if args.CacheResyncPeriodSeconds != nil && *args.CacheResyncPeriodSeconds <= 0 {
 		// remove for backward compatibility
-		delete(args, "cacheResyncPeriodSeconds")
+		args.CacheResyncPeriodSeconds = nil
 	}
-	return nil
 }
 
 func toJSON(v any) string {
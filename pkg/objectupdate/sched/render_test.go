@@ -17,10 +17,12 @@
 package sched
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/schedconfig"
 )
 
 func TestRenderConfig(t *testing.T) {
@@ -860,6 +862,233 @@ profiles:
 	}
 }
 
+func TestRenderConfigProfiles(t *testing.T) {
+	profileParams := []manifests.ConfigParams{
+		{
+			ProfileName: "tas-latency",
+			Cache: &manifests.ConfigCacheParams{
+				ResyncPeriodSeconds: newInt64(3),
+			},
+			ScoringStrategy: &manifests.ScoringStrategyParams{
+				Type: manifests.ScoringStrategyLeastAllocated,
+			},
+			LeaderElection: &manifests.LeaderElectionParams{
+				LeaderElect:       true,
+				ResourceNamespace: "tas-scheduler",
+				ResourceName:      "tas-leader",
+			},
+		},
+		{
+			ProfileName: "tas-batch",
+			Cache: &manifests.ConfigCacheParams{
+				ResyncPeriodSeconds: newInt64(10),
+			},
+			ScoringStrategy: &manifests.ScoringStrategyParams{
+				Type: manifests.ScoringStrategyMostAllocated,
+			},
+			PreemptionMode: newString(manifests.PreemptionDisabled),
+		},
+	}
+
+	data, ok, err := RenderConfigProfiles([]byte(configTemplateAllValuesMulti), "test-sched-name", profileParams)
+	if err != nil {
+		t.Fatalf("RenderConfigProfiles() failed: %v", err)
+	}
+	if !ok {
+		t.Errorf("expected update")
+	}
+	if !strings.Contains(string(data), "schedulerName: onlyResourceFit") {
+		t.Errorf("unrelated profile not preserved:\n%s", string(data))
+	}
+	if strings.Contains(string(data), "schedulerName: test-sched-name") {
+		t.Errorf("template profile not replaced:\n%s", string(data))
+	}
+
+	data, _, err = RenderConfigProfiles([]byte(configTemplateEmpty), "test-sched-name", profileParams)
+	if err != nil {
+		t.Fatalf("RenderConfigProfiles() failed: %v", err)
+	}
+	decoded, err := manifests.DecodeSchedulerProfilesFromData(data)
+	if err != nil {
+		t.Fatalf("DecodeSchedulerProfilesFromData() failed: %v", err)
+	}
+	if len(decoded) != len(profileParams) {
+		t.Fatalf("decoded %d profiles, expected %d:\n%s", len(decoded), len(profileParams), string(data))
+	}
+	for idx, expected := range profileParams {
+		got := decoded[idx]
+		if got.ProfileName != expected.ProfileName {
+			t.Errorf("profile %d: name %q expected %q", idx, got.ProfileName, expected.ProfileName)
+		}
+		if *got.Cache.ResyncPeriodSeconds != *expected.Cache.ResyncPeriodSeconds {
+			t.Errorf("profile %d: resync period %d expected %d", idx, *got.Cache.ResyncPeriodSeconds, *expected.Cache.ResyncPeriodSeconds)
+		}
+		if got.ScoringStrategy == nil || got.ScoringStrategy.Type != expected.ScoringStrategy.Type {
+			t.Errorf("profile %d: scoring strategy %v expected %v", idx, got.ScoringStrategy, expected.ScoringStrategy)
+		}
+		if diff := cmp.Diff(got.PreemptionMode, expected.PreemptionMode); diff != "" {
+			t.Errorf("profile %d: preemption mode differs: %s", idx, diff)
+		}
+		if diff := cmp.Diff(got.LeaderElection, profileParams[0].LeaderElection); diff != "" {
+			t.Errorf("profile %d: leader election differs: %s", idx, diff)
+		}
+	}
+}
+
+func TestRenderConfigProfilesErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		schedulerName string
+		params        []manifests.ConfigParams
+	}{
+		{
+			name:          "duplicate names",
+			schedulerName: "test-sched-name",
+			params: []manifests.ConfigParams{
+				{ProfileName: "foo"},
+				{ProfileName: "foo"},
+			},
+		},
+		{
+			name:          "duplicate implicit name",
+			schedulerName: "test-sched-name",
+			params: []manifests.ConfigParams{
+				{},
+				{ProfileName: "test-sched-name"},
+			},
+		},
+		{
+			name:          "missing template",
+			schedulerName: "missing",
+			params: []manifests.ConfigParams{
+				{ProfileName: "foo"},
+				{ProfileName: "bar"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, ok, err := RenderConfigProfiles([]byte(configTemplateEmpty), tc.schedulerName, tc.params)
+			if err == nil {
+				t.Errorf("unexpected success")
+			}
+			if ok || string(data) != configTemplateEmpty {
+				t.Errorf("data changed on error")
+			}
+		})
+	}
+}
+
+func TestRenderConfigPlugins(t *testing.T) {
+	weight := int32(5)
+	params := manifests.ConfigParams{
+		Plugins: []manifests.ConfigPluginParams{
+			{
+				Name:       "NodeResourcesFit",
+				DisabledAt: []string{"score"},
+			},
+			{
+				Name:      "Coscheduling",
+				EnabledAt: []string{"queueSort", "preFilter", "postFilter", "permit", "reserve"},
+				Args:      []byte(`{"permitWaitingTimeSeconds":10}`),
+			},
+			{
+				Name:      "NodeResourcesAllocatable",
+				EnabledAt: []string{"score"},
+				Weight:    &weight,
+			},
+		},
+	}
+
+	data, ok, err := RenderConfig([]byte(configTemplateEmpty), "test-sched-name", &params)
+	if err != nil {
+		t.Fatalf("RenderConfig() failed: %v", err)
+	}
+	if !ok {
+		t.Errorf("expected update")
+	}
+
+	cfg, err := schedconfig.Decode(data)
+	if err != nil {
+		t.Fatalf("cannot decode the rendered config: %v\n%s", err, string(data))
+	}
+	prof := cfg.Profile("test-sched-name")
+	if prof == nil || prof.Plugins == nil {
+		t.Fatalf("missing profile plugins:\n%s", string(data))
+	}
+
+	expectedScore := &schedconfig.PluginSet{
+		Enabled: []schedconfig.Plugin{
+			{Name: manifests.SchedulerPluginName},
+			{Name: "NodeResourcesAllocatable", Weight: &weight},
+		},
+		Disabled: []schedconfig.Plugin{
+			{Name: "NodeResourcesFit"},
+		},
+	}
+	if diff := cmp.Diff(expectedScore, prof.Plugins.Score); diff != "" {
+		t.Errorf("unexpected score plugins: %s", diff)
+	}
+	expectedReserve := &schedconfig.PluginSet{
+		Enabled: []schedconfig.Plugin{
+			{Name: manifests.SchedulerPluginName},
+			{Name: "Coscheduling"},
+		},
+	}
+	if diff := cmp.Diff(expectedReserve, prof.Plugins.Reserve); diff != "" {
+		t.Errorf("unexpected reserve plugins: %s", diff)
+	}
+	for _, set := range []*schedconfig.PluginSet{prof.Plugins.QueueSort, prof.Plugins.PreFilter, prof.Plugins.PostFilter, prof.Plugins.Permit} {
+		if set == nil || len(set.Enabled) != 1 || set.Enabled[0].Name != "Coscheduling" {
+			t.Errorf("unexpected plugins: %v", set)
+		}
+	}
+	args, ok := prof.PluginArgs("Coscheduling")
+	if !ok || string(args) != `{"permitWaitingTimeSeconds":10}` {
+		t.Errorf("unexpected Coscheduling args: %q", string(args))
+	}
+}
+
+func TestRenderConfigPluginsErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		plugin manifests.ConfigPluginParams
+	}{
+		{
+			name:   "missing name",
+			plugin: manifests.ConfigPluginParams{EnabledAt: []string{"score"}},
+		},
+		{
+			name:   "NodeResourceTopologyMatch is managed",
+			plugin: manifests.ConfigPluginParams{Name: manifests.SchedulerPluginName, DisabledAt: []string{"score"}},
+		},
+		{
+			name:   "unknown extension point",
+			plugin: manifests.ConfigPluginParams{Name: "Coscheduling", EnabledAt: []string{"preFlight"}},
+		},
+		{
+			name:   "malformed args",
+			plugin: manifests.ConfigPluginParams{Name: "Coscheduling", Args: []byte(`{"permitWaitingTimeSeconds":`)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			params := manifests.ConfigParams{
+				Plugins: []manifests.ConfigPluginParams{tc.plugin},
+			}
+			data, ok, err := RenderConfig([]byte(configTemplateEmpty), "test-sched-name", &params)
+			if err == nil {
+				t.Errorf("unexpected success")
+			}
+			if ok || string(data) != configTemplateEmpty {
+				t.Errorf("data changed on error")
+			}
+		})
+	}
+}
+
 var configTemplateEmpty string = `apiVersion: kubescheduler.config.k8s.io/v1beta3
 kind: KubeSchedulerConfiguration
 leaderElection:
@@ -25,6 +25,7 @@ import (
 	"github.com/k8stopologyawareschedwg/deployer/pkg/flagcodec"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/images"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 )
 
 func SchedulerDeployment(dp *appsv1.Deployment, pullIfNotPresent, ctrlPlaneAffinity bool, verbose int) {
@@ -53,6 +54,16 @@ func ControllerDeployment(dp *appsv1.Deployment, pullIfNotPresent, ctrlPlaneAffi
 	}
 }
 
+// PodSettings applies the settings to the pods of the scheduler or of the controller deployment.
+func PodSettings(dp *appsv1.Deployment, settings options.PodSettings) {
+	podSpec := &dp.Spec.Template.Spec // shortcut
+	var cnt *corev1.Container
+	if len(podSpec.Containers) > 0 {
+		cnt = &podSpec.Containers[0]
+	}
+	objectupdate.SetPodSettings(podSpec, cnt, settings)
+}
+
 func pullPolicy(pullIfNotPresent bool) corev1.PullPolicy {
 	if pullIfNotPresent {
 		return corev1.PullIfNotPresent
@@ -23,9 +23,12 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 )
 
 func TestSchedulerDeployment(t *testing.T) {
@@ -79,6 +82,44 @@ func TestSchedulerDeployment(t *testing.T) {
 	}
 }
 
+func TestPodSettings(t *testing.T) {
+	dp, err := manifests.Deployment(manifests.ComponentSchedulerPlugin, manifests.SubComponentSchedulerPluginController, "")
+	if err != nil {
+		t.Fatalf("cannot load the controller manifest: %v", err)
+	}
+	ControllerDeployment(dp, false, true)
+	PodSettings(dp, options.PodSettings{
+		Resources: &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		},
+		PriorityClassName: "infra-critical",
+		Tolerations: []corev1.Toleration{
+			{
+				Key:      "node-role.kubernetes.io/infra",
+				Operator: corev1.TolerationOpExists,
+			},
+		},
+	})
+
+	podSpec := dp.Spec.Template.Spec // shortcut
+	if podSpec.PriorityClassName != "infra-critical" {
+		t.Errorf("unexpected priority class: %q", podSpec.PriorityClassName)
+	}
+	// the control plane tolerations are kept
+	if len(podSpec.Tolerations) != 3 {
+		t.Errorf("unexpected tolerations: %v", podSpec.Tolerations)
+	}
+	limit := podSpec.Containers[0].Resources.Limits[corev1.ResourceMemory]
+	if limit.String() != "1Gi" {
+		t.Errorf("unexpected memory limit: %v", limit.String())
+	}
+	if _, ok := podSpec.Containers[0].Resources.Requests[corev1.ResourceCPU]; !ok {
+		t.Errorf("cpu request lost: %v", podSpec.Containers[0].Resources.Requests)
+	}
+}
+
 // to make the image (which we change regularly) invariant for the tests
 func fixSchedulerImage(dp *appsv1.Deployment) {
 	cnt := &dp.Spec.Template.Spec.Containers[0] // shortcut
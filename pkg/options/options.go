@@ -19,6 +19,7 @@ package options
 import (
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
@@ -35,6 +36,15 @@ func IsValidSCCVersion(ver string) bool {
 	return ver == string(SCCV1) || ver == string(SCCV2)
 }
 
+const (
+	DryRunNone   = "none"
+	DryRunServer = "server"
+)
+
+func IsValidDryRun(mode string) bool {
+	return mode == "" || mode == DryRunNone || mode == DryRunServer
+}
+
 type Options struct {
 	UserPlatform                platform.Platform
 	UserPlatformVersion         platform.Version
@@ -50,6 +60,14 @@ type Options struct {
 	UpdaterSyncPeriod           time.Duration
 	UpdaterVerbose              int
 	SchedProfileName            string
+	SchedProfiles               []SchedulerProfile
+	SchedPlugins                []SchedulerPlugin
+	SchedPodSettings            PodSettings
+	SchedCtrlPodSettings        PodSettings
+	UpdaterPodSettings          PodSettings
+	SchedPreemptionMode         string
+	SchedInformerMode           string
+	SchedConfigAPIVersion       string
 	SchedResyncPeriod           time.Duration
 	SchedVerbose                int
 	SchedCtrlPlaneAffinity      bool
@@ -59,17 +77,27 @@ type Options struct {
 	ClusterPlatform             platform.Platform
 	ClusterVersion              platform.Version
 	WaitCompletion              bool
+	Apply                       bool
+	Transactional               bool
+	DryRun                      string
 	SchedScoringStratConfigData string
 	SchedCacheParamsConfigData  string
+	InstallID                   string
+	InventoryNamespace          string
+	Parallelism                 int
 }
 
 type API struct {
-	Platform platform.Platform
+	Platform  platform.Platform
+	Apply     bool
+	InstallID string
 }
 
 type Scheduler struct {
 	Platform               platform.Platform
+	PlatformVersion        platform.Version
 	WaitCompletion         bool
+	Apply                  bool
 	Replicas               int32
 	ProfileName            string
 	PullIfNotPresent       bool
@@ -80,7 +108,58 @@ type Scheduler struct {
 	Verbose                int
 	ScoringStratConfigData string
 	CacheParamsConfigData  string
-	Namespace              string
+	// PreemptionMode, if set, is the preemption mode of all the profiles which don't set their own
+	PreemptionMode string
+	// InformerMode, if set, is the cache informer mode of all the profiles, overriding the cache params
+	InformerMode string
+	// ConfigAPIVersion selects the API version of the scheduler configuration: "auto" (or empty), "v1beta3" or "v1"
+	ConfigAPIVersion string
+	Namespace        string
+	InstallID        string
+	// Profiles are rendered in the scheduler configuration besides the one named ProfileName
+	Profiles []SchedulerProfile
+	// Plugins change the plugins of all the profiles which don't set their own
+	Plugins []SchedulerPlugin
+	// SchedulerPod and ControllerPod tune the pods of the scheduler and of the controller
+	SchedulerPod  PodSettings
+	ControllerPod PodSettings
+}
+
+// SchedulerProfile is an additional profile of the scheduler. The configuration
+// data, the preemption mode and the plugins left empty are inherited from the scheduler-wide ones.
+type SchedulerProfile struct {
+	Name                   string
+	ScoringStratConfigData string
+	CacheParamsConfigData  string
+	PreemptionMode         string
+	Plugins                []SchedulerPlugin
+}
+
+// SchedulerPlugin enables or disables a plugin of the scheduler profiles at the given
+// extension points (e.g. "queueSort", "score"), and sets its arguments (pluginConfig).
+type SchedulerPlugin struct {
+	Name       string
+	EnabledAt  []string
+	DisabledAt []string
+	// Weight is the score weight, zero means the scheduler default
+	Weight int32
+	// ArgsData are the plugin arguments, YAML or JSON
+	ArgsData string
+}
+
+// PodSettings tunes the resources and the placement of the pods of a workload.
+// The fields left empty don't change the rendered manifests.
+type PodSettings struct {
+	// Resources are merged in the ones of the main container, by resource name
+	Resources         *corev1.ResourceRequirements
+	PriorityClassName string
+	// Tolerations are added to the ones of the pod
+	Tolerations []corev1.Toleration
+	// NodeAffinity is merged in the one of the pod: the nodes must satisfy both the required terms,
+	// the preferred terms are added. TopologySpreadConstraints and PodAntiAffinity replace the ones of the pod
+	NodeAffinity              *corev1.NodeAffinity
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint
+	PodAntiAffinity           *corev1.PodAntiAffinity
 }
 
 type DaemonSet struct {
@@ -91,6 +170,7 @@ type DaemonSet struct {
 	NodeSelector       *metav1.LabelSelector
 	UpdateInterval     time.Duration
 	SCCVersion         SCCVersion
+	Pod                PodSettings
 }
 
 type UpdaterDaemon struct {
@@ -105,10 +185,12 @@ type Updater struct {
 	Platform            platform.Platform
 	PlatformVersion     platform.Version
 	WaitCompletion      bool
+	Apply               bool
 	RTEConfigData       string
 	DaemonSet           DaemonSet
 	EnableCRIHooks      bool
 	CustomSELinuxPolicy bool
+	InstallID           string
 }
 
 type Render struct {
@@ -127,5 +209,6 @@ func ForDaemonSet(commonOpts *Options) DaemonSet {
 		UpdateInterval:     commonOpts.UpdaterSyncPeriod,
 		SCCVersion:         commonOpts.UpdaterSCCVersion,
 		Verbose:            commonOpts.UpdaterVerbose,
+		Pod:                commonOpts.UpdaterPodSettings,
 	}
 }
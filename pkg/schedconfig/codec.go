@@ -0,0 +1,359 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package schedconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+)
+
+// Decode decodes and validates a scheduler configuration, YAML or JSON, rejecting unknown fields.
+func Decode(data []byte) (*Configuration, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, fmt.Errorf("empty scheduler configuration")
+	}
+	cfg := Configuration{}
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return nil, fmt.Errorf("cannot decode scheduler configuration: %w", err)
+	}
+	if err := Validate(&cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Encode validates and encodes a scheduler configuration as YAML.
+func Encode(cfg *Configuration) ([]byte, error) {
+	if err := Validate(cfg); err != nil {
+		return nil, err
+	}
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode scheduler configuration: %w", err)
+	}
+	return data, nil
+}
+
+// Validate checks the consistency of the scheduler configuration, and the
+// arguments of the NodeResourceTopologyMatch plugin in all the profiles.
+func Validate(cfg *Configuration) error {
+	if cfg.Kind != Kind {
+		return fmt.Errorf("unsupported scheduler configuration kind: %q", cfg.Kind)
+	}
+	if cfg.APIVersion != manifests.SchedulerConfigAPIVersionV1beta3 && cfg.APIVersion != manifests.SchedulerConfigAPIVersionV1 {
+		return fmt.Errorf("unsupported scheduler configuration apiVersion: %q", cfg.APIVersion)
+	}
+
+	profileNames := make(map[string]bool)
+	for idx := range cfg.Profiles {
+		prof := &cfg.Profiles[idx]
+		if prof.SchedulerName == "" {
+			return fmt.Errorf("profile #%d: missing schedulerName", idx)
+		}
+		if profileNames[prof.SchedulerName] {
+			return fmt.Errorf("duplicate profile: %q", prof.SchedulerName)
+		}
+		profileNames[prof.SchedulerName] = true
+
+		pluginNames := make(map[string]bool)
+		for _, pluginConf := range prof.PluginConfig {
+			if pluginConf.Name == "" {
+				return fmt.Errorf("profile %q: pluginConfig with missing name", prof.SchedulerName)
+			}
+			if pluginNames[pluginConf.Name] {
+				return fmt.Errorf("profile %q: duplicate pluginConfig: %q", prof.SchedulerName, pluginConf.Name)
+			}
+			pluginNames[pluginConf.Name] = true
+		}
+
+		if _, _, err := prof.NodeResourceTopologyMatchArgs(); err != nil {
+			return fmt.Errorf("profile %q: %w", prof.SchedulerName, err)
+		}
+	}
+	return nil
+}
+
+// Profile returns the profile with the given scheduler name, or nil if missing.
+func (cfg *Configuration) Profile(schedulerName string) *Profile {
+	for idx := range cfg.Profiles {
+		if cfg.Profiles[idx].SchedulerName == schedulerName {
+			return &cfg.Profiles[idx]
+		}
+	}
+	return nil
+}
+
+// PluginArgs returns the raw arguments of the plugin `name`, and false if the profile has no configuration for it.
+func (prof *Profile) PluginArgs(name string) (json.RawMessage, bool) {
+	for _, pluginConf := range prof.PluginConfig {
+		if pluginConf.Name == name {
+			return pluginConf.Args, true
+		}
+	}
+	return nil, false
+}
+
+// SetPluginArgs sets the raw arguments of the plugin `name`, adding its configuration if missing.
+func (prof *Profile) SetPluginArgs(name string, args json.RawMessage) {
+	for idx := range prof.PluginConfig {
+		if prof.PluginConfig[idx].Name == name {
+			prof.PluginConfig[idx].Args = args
+			return
+		}
+	}
+	prof.PluginConfig = append(prof.PluginConfig, PluginConfig{
+		Name: name,
+		Args: args,
+	})
+}
+
+// NodeResourceTopologyMatchArgs returns the decoded arguments of the NodeResourceTopologyMatch plugin,
+// and false if the profile has no configuration for it.
+func (prof *Profile) NodeResourceTopologyMatchArgs() (*NodeResourceTopologyMatchArgs, bool, error) {
+	raw, ok := prof.PluginArgs(manifests.SchedulerPluginName)
+	if !ok {
+		return nil, false, nil
+	}
+	args, err := DecodeNodeResourceTopologyMatchArgs(raw)
+	return args, true, err
+}
+
+// SetNodeResourceTopologyMatchArgs validates and sets the arguments of the NodeResourceTopologyMatch plugin.
+func (prof *Profile) SetNodeResourceTopologyMatchArgs(args *NodeResourceTopologyMatchArgs) error {
+	if err := ValidateNodeResourceTopologyMatchArgs(args); err != nil {
+		return err
+	}
+	raw, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+	prof.SetPluginArgs(manifests.SchedulerPluginName, raw)
+	return nil
+}
+
+// DecodeNodeResourceTopologyMatchArgs decodes and validates the arguments of the NodeResourceTopologyMatch plugin,
+// rejecting unknown fields. Missing arguments decode as empty arguments.
+func DecodeNodeResourceTopologyMatchArgs(raw json.RawMessage) (*NodeResourceTopologyMatchArgs, error) {
+	args := NodeResourceTopologyMatchArgs{}
+	if len(raw) == 0 || string(raw) == "null" {
+		return &args, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&args); err != nil {
+		return nil, fmt.Errorf("cannot decode %s args: %w", manifests.SchedulerPluginName, err)
+	}
+	if err := ValidateNodeResourceTopologyMatchArgs(&args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+func ValidateNodeResourceTopologyMatchArgs(args *NodeResourceTopologyMatchArgs) error {
+	if args.ScoringStrategy != nil && args.ScoringStrategy.Type != "" {
+		if err := manifests.ValidateScoringStrategyType(args.ScoringStrategy.Type); err != nil {
+			return err
+		}
+	}
+	if args.ScoringStrategy != nil {
+		for idx, res := range args.ScoringStrategy.Resources {
+			if res.Name == "" {
+				return fmt.Errorf("scoringStrategy.resources[%d]: missing name", idx)
+			}
+		}
+	}
+	if args.CacheResyncPeriodSeconds != nil && *args.CacheResyncPeriodSeconds < 0 {
+		return fmt.Errorf("negative cacheResyncPeriodSeconds: %d", *args.CacheResyncPeriodSeconds)
+	}
+	if args.Cache != nil {
+		if args.Cache.ForeignPodsDetect != nil {
+			if err := manifests.ValidateForeignPodsDetectMode(*args.Cache.ForeignPodsDetect); err != nil {
+				return err
+			}
+		}
+		if args.Cache.ResyncMethod != nil {
+			if err := manifests.ValidateCacheResyncMethod(*args.Cache.ResyncMethod); err != nil {
+				return err
+			}
+		}
+		if args.Cache.InformerMode != nil {
+			if err := manifests.ValidateCacheInformerMode(*args.Cache.InformerMode); err != nil {
+				return err
+			}
+		}
+	}
+	if args.PreemptionMode != nil {
+		if err := manifests.ValidatePreemptionMode(*args.PreemptionMode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Clone returns a deep copy of the profile.
+func (prof *Profile) Clone() (Profile, error) {
+	ret := Profile{}
+	data, err := json.Marshal(prof)
+	if err != nil {
+		return ret, err
+	}
+	err = json.Unmarshal(data, &ret)
+	return ret, err
+}
+
+// ExtensionPoints are the names of the extension points, in the order of the scheduling cycle.
+var ExtensionPoints = []string{
+	"multiPoint",
+	"preEnqueue",
+	"queueSort",
+	"preFilter",
+	"filter",
+	"postFilter",
+	"preScore",
+	"score",
+	"reserve",
+	"permit",
+	"preBind",
+	"bind",
+	"postBind",
+}
+
+// PluginSet returns the plugin set of the extension point, which is nil if not set.
+func (plugins *Plugins) PluginSet(extPoint string) (*PluginSet, error) {
+	ref, err := plugins.pluginSetRef(extPoint)
+	if err != nil {
+		return nil, err
+	}
+	return *ref, nil
+}
+
+// SetPluginSet sets the plugin set of the extension point.
+func (plugins *Plugins) SetPluginSet(extPoint string, set *PluginSet) error {
+	ref, err := plugins.pluginSetRef(extPoint)
+	if err != nil {
+		return err
+	}
+	*ref = set
+	return nil
+}
+
+// PluginNames returns the names of the plugins enabled at any extension point.
+func (plugins *Plugins) PluginNames() []string {
+	var names []string
+	for _, extPoint := range ExtensionPoints {
+		set, _ := plugins.PluginSet(extPoint)
+		if set == nil {
+			continue
+		}
+		for _, plugin := range set.Enabled {
+			names = append(names, plugin.Name)
+		}
+	}
+	return names
+}
+
+// EnablePlugin enables the plugin at the extension point, removing it from the disabled plugins.
+// If the plugin is already enabled, its weight is updated.
+func (plugins *Plugins) EnablePlugin(extPoint string, plugin Plugin) error {
+	ref, err := plugins.pluginSetRef(extPoint)
+	if err != nil {
+		return err
+	}
+	if *ref == nil {
+		*ref = &PluginSet{}
+	}
+	set := *ref
+	set.Disabled = removePlugin(set.Disabled, plugin.Name)
+	for idx := range set.Enabled {
+		if set.Enabled[idx].Name == plugin.Name {
+			set.Enabled[idx].Weight = plugin.Weight
+			return nil
+		}
+	}
+	set.Enabled = append(set.Enabled, plugin)
+	return nil
+}
+
+// DisablePlugin disables the plugin at the extension point, removing it from the enabled plugins.
+// The name "*" disables all the default plugins of the extension point.
+func (plugins *Plugins) DisablePlugin(extPoint, name string) error {
+	ref, err := plugins.pluginSetRef(extPoint)
+	if err != nil {
+		return err
+	}
+	if *ref == nil {
+		*ref = &PluginSet{}
+	}
+	set := *ref
+	set.Enabled = removePlugin(set.Enabled, name)
+	for _, plugin := range set.Disabled {
+		if plugin.Name == name {
+			return nil
+		}
+	}
+	set.Disabled = append(set.Disabled, Plugin{Name: name})
+	return nil
+}
+
+func removePlugin(plugins []Plugin, name string) []Plugin {
+	var ret []Plugin
+	for _, plugin := range plugins {
+		if plugin.Name == name {
+			continue
+		}
+		ret = append(ret, plugin)
+	}
+	return ret
+}
+
+func (plugins *Plugins) pluginSetRef(extPoint string) (**PluginSet, error) {
+	switch extPoint {
+	case "multiPoint":
+		return &plugins.MultiPoint, nil
+	case "preEnqueue":
+		return &plugins.PreEnqueue, nil
+	case "queueSort":
+		return &plugins.QueueSort, nil
+	case "preFilter":
+		return &plugins.PreFilter, nil
+	case "filter":
+		return &plugins.Filter, nil
+	case "postFilter":
+		return &plugins.PostFilter, nil
+	case "preScore":
+		return &plugins.PreScore, nil
+	case "score":
+		return &plugins.Score, nil
+	case "reserve":
+		return &plugins.Reserve, nil
+	case "permit":
+		return &plugins.Permit, nil
+	case "preBind":
+		return &plugins.PreBind, nil
+	case "bind":
+		return &plugins.Bind, nil
+	case "postBind":
+		return &plugins.PostBind, nil
+	default:
+		return nil, fmt.Errorf("unknown extension point: %q", extPoint)
+	}
+}
@@ -0,0 +1,390 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package schedconfig
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+)
+
+func TestRoundTrip(t *testing.T) {
+	cm, err := manifests.ConfigMap(manifests.ComponentSchedulerPlugin, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	type testCase struct {
+		name string
+		data string
+	}
+
+	testCases := []testCase{
+		{
+			name: "embedded template",
+			data: cm.Data[manifests.SchedulerConfigFileName],
+		},
+		{
+			name: "all fields",
+			data: configFull,
+		},
+		{
+			name: "JSON",
+			data: `{"apiVersion": "kubescheduler.config.k8s.io/v1", "kind": "KubeSchedulerConfiguration", "profiles": [{"schedulerName": "foo"}]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := Decode([]byte(tc.data))
+			if err != nil {
+				t.Fatalf("Decode() failed: %v", err)
+			}
+			data, err := Encode(cfg)
+			if err != nil {
+				t.Fatalf("Encode() failed: %v", err)
+			}
+
+			// nothing is lost: the encoded data is the original data, modulo formatting
+			var expected, got interface{}
+			if err := yaml.Unmarshal([]byte(tc.data), &expected); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := yaml.Unmarshal(data, &got); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(expected, got); diff != "" {
+				t.Errorf("round trip changed the data: %s", diff)
+			}
+
+			cfg2, err := Decode(data)
+			if err != nil {
+				t.Fatalf("Decode() of encoded data failed: %v", err)
+			}
+			if !reflect.DeepEqual(cfg, cfg2) {
+				t.Errorf("round trip changed the configuration:\n%s", cmp.Diff(cfg, cfg2))
+			}
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	type testCase struct {
+		name        string
+		data        string
+		expectedErr string
+	}
+
+	testCases := []testCase{
+		{
+			name:        "empty",
+			data:        "",
+			expectedErr: "empty",
+		},
+		{
+			name:        "malformed",
+			data:        "apiVersion: [",
+			expectedErr: "cannot decode",
+		},
+		{
+			name:        "unknown field",
+			data:        strings.Replace(configMinimal, "profiles:", "percentageOfNodesToScroe: 10\nprofiles:", 1),
+			expectedErr: "unknown field",
+		},
+		{
+			name:        "unknown profile field",
+			data:        strings.Replace(configMinimal, "- schedulerName: foo", "- schedulerName: foo\n  plugin: {}", 1),
+			expectedErr: "unknown field",
+		},
+		{
+			name:        "duplicate key",
+			data:        strings.Replace(configMinimal, "kind: KubeSchedulerConfiguration", "kind: KubeSchedulerConfiguration\nkind: KubeSchedulerConfiguration", 1),
+			expectedErr: "already set",
+		},
+		{
+			name:        "wrong kind",
+			data:        strings.Replace(configMinimal, "kind: KubeSchedulerConfiguration", "kind: KubeletConfiguration", 1),
+			expectedErr: "unsupported scheduler configuration kind",
+		},
+		{
+			name:        "wrong apiVersion",
+			data:        strings.Replace(configMinimal, "/v1\n", "/v1beta2\n", 1),
+			expectedErr: "unsupported scheduler configuration apiVersion",
+		},
+		{
+			name:        "missing profile name",
+			data:        strings.Replace(configMinimal, "- schedulerName: foo", "- schedulerName: \"\"", 1),
+			expectedErr: "missing schedulerName",
+		},
+		{
+			name:        "duplicate profile",
+			data:        configMinimal + "- schedulerName: foo\n",
+			expectedErr: "duplicate profile",
+		},
+		{
+			name:        "unknown args field",
+			data:        configMinimal + "  pluginConfig:\n  - name: NodeResourceTopologyMatch\n    args:\n      cacheResyncPeriod: 5\n",
+			expectedErr: "unknown field",
+		},
+		{
+			name:        "invalid args value",
+			data:        configMinimal + "  pluginConfig:\n  - name: NodeResourceTopologyMatch\n    args:\n      scoringStrategy:\n        type: MostlyAllocated\n",
+			expectedErr: "unsupported scoringStrategyType",
+		},
+		{
+			name:        "duplicate plugin config",
+			data:        configMinimal + "  pluginConfig:\n  - name: Foo\n  - name: Foo\n",
+			expectedErr: "duplicate pluginConfig",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Decode([]byte(tc.data))
+			if err == nil {
+				t.Fatalf("unexpected success")
+			}
+			if !strings.Contains(err.Error(), tc.expectedErr) {
+				t.Errorf("unexpected error: %v (expected %q)", err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestSetNodeResourceTopologyMatchArgs(t *testing.T) {
+	cfg, err := Decode([]byte(configMinimal))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	prof := cfg.Profile("foo")
+	if prof == nil {
+		t.Fatalf("missing profile")
+	}
+	if _, ok, err := prof.NodeResourceTopologyMatchArgs(); ok || err != nil {
+		t.Fatalf("unexpected args: ok=%v err=%v", ok, err)
+	}
+
+	invalid := "Sometimes"
+	if err := prof.SetNodeResourceTopologyMatchArgs(&NodeResourceTopologyMatchArgs{PreemptionMode: &invalid}); err == nil {
+		t.Errorf("unexpected success setting invalid args")
+	}
+
+	resync := int64(7)
+	args := &NodeResourceTopologyMatchArgs{
+		CacheResyncPeriodSeconds: &resync,
+		ScoringStrategy: &ScoringStrategy{
+			Type:      manifests.ScoringStrategyBalancedAllocation,
+			Resources: []ResourceSpec{{Name: "cpu", Weight: 2}},
+		},
+	}
+	if err := prof.SetNodeResourceTopologyMatchArgs(args); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// setting again replaces
+	if err := prof.SetNodeResourceTopologyMatchArgs(args); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prof.PluginConfig) != 1 {
+		t.Errorf("unexpected plugin config: %v", prof.PluginConfig)
+	}
+
+	data, err := Encode(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg2, err := Decode(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, ok, err := cfg2.Profile("foo").NodeResourceTopologyMatchArgs()
+	if !ok || err != nil {
+		t.Fatalf("unexpected args: ok=%v err=%v", ok, err)
+	}
+	if !reflect.DeepEqual(got, args) {
+		t.Errorf("args got %v expected %v", got, args)
+	}
+}
+
+func TestEnableDisablePlugin(t *testing.T) {
+	weight := int32(3)
+	testCases := []struct {
+		name     string
+		plugins  Plugins
+		update   func(plugins *Plugins) error
+		expected Plugins
+	}{
+		{
+			name:    "enable on empty",
+			plugins: Plugins{},
+			update: func(plugins *Plugins) error {
+				return plugins.EnablePlugin("queueSort", Plugin{Name: "Coscheduling"})
+			},
+			expected: Plugins{
+				QueueSort: &PluginSet{Enabled: []Plugin{{Name: "Coscheduling"}}},
+			},
+		},
+		{
+			name: "enable updates the weight",
+			plugins: Plugins{
+				Score: &PluginSet{Enabled: []Plugin{{Name: "NodeResourceTopologyMatch"}}},
+			},
+			update: func(plugins *Plugins) error {
+				return plugins.EnablePlugin("score", Plugin{Name: "NodeResourceTopologyMatch", Weight: &weight})
+			},
+			expected: Plugins{
+				Score: &PluginSet{Enabled: []Plugin{{Name: "NodeResourceTopologyMatch", Weight: &weight}}},
+			},
+		},
+		{
+			name: "enable removes from disabled",
+			plugins: Plugins{
+				Score: &PluginSet{Disabled: []Plugin{{Name: "NodeResourcesFit"}}},
+			},
+			update: func(plugins *Plugins) error {
+				return plugins.EnablePlugin("score", Plugin{Name: "NodeResourcesFit"})
+			},
+			expected: Plugins{
+				Score: &PluginSet{Enabled: []Plugin{{Name: "NodeResourcesFit"}}},
+			},
+		},
+		{
+			name: "disable removes from enabled, once",
+			plugins: Plugins{
+				Score: &PluginSet{Enabled: []Plugin{{Name: "NodeResourcesFit"}, {Name: "NodeResourceTopologyMatch"}}},
+			},
+			update: func(plugins *Plugins) error {
+				if err := plugins.DisablePlugin("score", "NodeResourcesFit"); err != nil {
+					return err
+				}
+				return plugins.DisablePlugin("score", "NodeResourcesFit")
+			},
+			expected: Plugins{
+				Score: &PluginSet{
+					Enabled:  []Plugin{{Name: "NodeResourceTopologyMatch"}},
+					Disabled: []Plugin{{Name: "NodeResourcesFit"}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			plugins := tc.plugins
+			if err := tc.update(&plugins); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, plugins); diff != "" {
+				t.Errorf("unexpected plugins: %s", diff)
+			}
+		})
+	}
+
+	plugins := Plugins{}
+	if err := plugins.EnablePlugin("preFlight", Plugin{Name: "Foo"}); err == nil {
+		t.Errorf("unexpected success on unknown extension point")
+	}
+	if err := plugins.DisablePlugin("preFlight", "Foo"); err == nil {
+		t.Errorf("unexpected success on unknown extension point")
+	}
+}
+
+const configMinimal = `apiVersion: kubescheduler.config.k8s.io/v1
+kind: KubeSchedulerConfiguration
+profiles:
+- schedulerName: foo
+`
+
+const configFull = `apiVersion: kubescheduler.config.k8s.io/v1
+kind: KubeSchedulerConfiguration
+parallelism: 16
+percentageOfNodesToScore: 50
+podInitialBackoffSeconds: 1
+podMaxBackoffSeconds: 10
+enableProfiling: true
+enableContentionProfiling: false
+delayCacheUntilActive: true
+clientConnection:
+  kubeconfig: /etc/kubernetes/scheduler.conf
+  contentType: application/vnd.kubernetes.protobuf
+  qps: 50
+  burst: 100
+leaderElection:
+  leaderElect: true
+  leaseDuration: 15s
+  renewDeadline: 10s
+  retryPeriod: 2s
+  resourceLock: leases
+  resourceName: nrtmatch-scheduler
+  resourceNamespace: tas-scheduler
+extenders:
+- urlPrefix: http://127.0.0.1:8888/
+  filterVerb: filter
+profiles:
+- schedulerName: topology-aware-scheduler
+  percentageOfNodesToScore: 100
+  plugins:
+    multiPoint:
+      enabled:
+      - name: NodeResourceTopologyMatch
+    filter:
+      enabled:
+      - name: NodeResourceTopologyMatch
+    reserve:
+      enabled:
+      - name: NodeResourceTopologyMatch
+    score:
+      disabled:
+      - name: NodeResourcesFit
+      enabled:
+      - name: NodeResourceTopologyMatch
+        weight: 3
+    permit:
+      enabled:
+      - name: Coscheduling
+  pluginConfig:
+  - name: NodeResourceTopologyMatch
+    args:
+      cacheResyncPeriodSeconds: 5
+      discardReservedNodes: true
+      preemptionMode: Enabled
+      cache:
+        foreignPodsDetect: OnlyExclusiveResources
+        informerMode: Dedicated
+        resyncMethod: Autodetect
+        resyncScope: OnlyExclusiveResources
+      scoringStrategy:
+        type: MostAllocated
+        resources:
+        - name: cpu
+          weight: 2
+  - name: Coscheduling
+    args:
+      permitWaitingTimeSeconds: 10
+- schedulerName: only-fit
+  plugins:
+    filter:
+      disabled:
+      - name: '*'
+      enabled:
+      - name: NodeResourcesFit
+`
@@ -0,0 +1,79 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package schedconfig
+
+import (
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+)
+
+// ProfileParams returns the parameters of the profiles which configure the NodeResourceTopologyMatch plugin.
+// It is the strict counterpart of manifests.DecodeSchedulerProfilesFromData, with the same output.
+func (cfg *Configuration) ProfileParams() ([]manifests.ConfigParams, error) {
+	var electParams *manifests.LeaderElectionParams
+	if lead := cfg.LeaderElection; lead != nil {
+		electParams = &manifests.LeaderElectionParams{
+			ResourceNamespace: lead.ResourceNamespace,
+			ResourceName:      lead.ResourceName,
+		}
+		if lead.LeaderElect != nil {
+			electParams.LeaderElect = *lead.LeaderElect
+		}
+	}
+
+	params := []manifests.ConfigParams{}
+	for idx := range cfg.Profiles {
+		prof := &cfg.Profiles[idx]
+		args, ok, err := prof.NodeResourceTopologyMatchArgs()
+		if err != nil {
+			return params, err
+		}
+		if !ok {
+			continue
+		}
+		profileParams := paramsFromArgs(args)
+		profileParams.ProfileName = prof.SchedulerName
+		profileParams.LeaderElection = electParams
+		params = append(params, profileParams)
+	}
+	return params, nil
+}
+
+func paramsFromArgs(args *NodeResourceTopologyMatchArgs) manifests.ConfigParams {
+	params := manifests.ConfigParams{
+		Cache: &manifests.ConfigCacheParams{
+			ResyncPeriodSeconds: args.CacheResyncPeriodSeconds,
+		},
+		PreemptionMode: args.PreemptionMode,
+	}
+	if args.Cache != nil {
+		params.Cache.ResyncMethod = args.Cache.ResyncMethod
+		params.Cache.ForeignPodsDetectMode = args.Cache.ForeignPodsDetect
+		params.Cache.InformerMode = args.Cache.InformerMode
+	}
+	if args.ScoringStrategy != nil {
+		params.ScoringStrategy = &manifests.ScoringStrategyParams{
+			Type: args.ScoringStrategy.Type,
+		}
+		for _, res := range args.ScoringStrategy.Resources {
+			params.ScoringStrategy.Resources = append(params.ScoringStrategy.Resources, manifests.ResourceSpecParams{
+				Name:   res.Name,
+				Weight: res.Weight,
+			})
+		}
+	}
+	return params
+}
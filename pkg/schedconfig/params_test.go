@@ -0,0 +1,67 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package schedconfig
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+)
+
+func TestProfileParams(t *testing.T) {
+	type testCase struct {
+		name string
+		data string
+	}
+
+	testCases := []testCase{
+		{
+			name: "no args",
+			data: configMinimal,
+		},
+		{
+			name: "empty args",
+			data: configMinimal + "  pluginConfig:\n  - name: NodeResourceTopologyMatch\n    args: {}\n",
+		},
+		{
+			name: "all args",
+			data: configFull,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := Decode([]byte(tc.data))
+			if err != nil {
+				t.Fatalf("Decode() failed: %v", err)
+			}
+			got, err := cfg.ProfileParams()
+			if err != nil {
+				t.Fatalf("ProfileParams() failed: %v", err)
+			}
+			expected, err := manifests.DecodeSchedulerProfilesFromData([]byte(tc.data))
+			if err != nil {
+				t.Fatalf("DecodeSchedulerProfilesFromData() failed: %v", err)
+			}
+			if diff := cmp.Diff(expected, got); diff != "" {
+				t.Errorf("params differ from the lenient decoder: %s", diff)
+			}
+		})
+	}
+}
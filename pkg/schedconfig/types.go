@@ -0,0 +1,134 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+// Package schedconfig is a typed model of the subset of the kube-scheduler configuration
+// (KubeSchedulerConfiguration) the deployer manages. All the fields of the supported API
+// versions are modeled, so decoding can reject unknown fields without losing data.
+package schedconfig
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	Kind = "KubeSchedulerConfiguration"
+)
+
+// Configuration is the KubeSchedulerConfiguration.
+type Configuration struct {
+	APIVersion                string                         `json:"apiVersion"`
+	Kind                      string                         `json:"kind"`
+	Parallelism               *int32                         `json:"parallelism,omitempty"`
+	LeaderElection            *LeaderElectionConfiguration   `json:"leaderElection,omitempty"`
+	ClientConnection          *ClientConnectionConfiguration `json:"clientConnection,omitempty"`
+	EnableProfiling           *bool                          `json:"enableProfiling,omitempty"`
+	EnableContentionProfiling *bool                          `json:"enableContentionProfiling,omitempty"`
+	PercentageOfNodesToScore  *int32                         `json:"percentageOfNodesToScore,omitempty"`
+	PodInitialBackoffSeconds  *int64                         `json:"podInitialBackoffSeconds,omitempty"`
+	PodMaxBackoffSeconds      *int64                         `json:"podMaxBackoffSeconds,omitempty"`
+	DelayCacheUntilActive     *bool                          `json:"delayCacheUntilActive,omitempty"`
+	Profiles                  []Profile                      `json:"profiles,omitempty"`
+	// Extenders are not managed by the deployer, so they are passed through as they are.
+	Extenders []json.RawMessage `json:"extenders,omitempty"`
+}
+
+type LeaderElectionConfiguration struct {
+	LeaderElect       *bool            `json:"leaderElect,omitempty"`
+	LeaseDuration     *metav1.Duration `json:"leaseDuration,omitempty"`
+	RenewDeadline     *metav1.Duration `json:"renewDeadline,omitempty"`
+	RetryPeriod       *metav1.Duration `json:"retryPeriod,omitempty"`
+	ResourceLock      string           `json:"resourceLock,omitempty"`
+	ResourceName      string           `json:"resourceName,omitempty"`
+	ResourceNamespace string           `json:"resourceNamespace,omitempty"`
+}
+
+type ClientConnectionConfiguration struct {
+	Kubeconfig         string   `json:"kubeconfig,omitempty"`
+	AcceptContentTypes string   `json:"acceptContentTypes,omitempty"`
+	ContentType        string   `json:"contentType,omitempty"`
+	QPS                *float32 `json:"qps,omitempty"`
+	Burst              *int32   `json:"burst,omitempty"`
+}
+
+// Profile is a scheduling profile.
+type Profile struct {
+	SchedulerName            string         `json:"schedulerName"`
+	PercentageOfNodesToScore *int32         `json:"percentageOfNodesToScore,omitempty"`
+	Plugins                  *Plugins       `json:"plugins,omitempty"`
+	PluginConfig             []PluginConfig `json:"pluginConfig,omitempty"`
+}
+
+// Plugins holds the plugins to enable or disable at each extension point.
+type Plugins struct {
+	PreEnqueue *PluginSet `json:"preEnqueue,omitempty"`
+	QueueSort  *PluginSet `json:"queueSort,omitempty"`
+	PreFilter  *PluginSet `json:"preFilter,omitempty"`
+	Filter     *PluginSet `json:"filter,omitempty"`
+	PostFilter *PluginSet `json:"postFilter,omitempty"`
+	PreScore   *PluginSet `json:"preScore,omitempty"`
+	Score      *PluginSet `json:"score,omitempty"`
+	Reserve    *PluginSet `json:"reserve,omitempty"`
+	Permit     *PluginSet `json:"permit,omitempty"`
+	PreBind    *PluginSet `json:"preBind,omitempty"`
+	Bind       *PluginSet `json:"bind,omitempty"`
+	PostBind   *PluginSet `json:"postBind,omitempty"`
+	MultiPoint *PluginSet `json:"multiPoint,omitempty"`
+}
+
+type PluginSet struct {
+	Enabled  []Plugin `json:"enabled,omitempty"`
+	Disabled []Plugin `json:"disabled,omitempty"`
+}
+
+type Plugin struct {
+	Name   string `json:"name"`
+	Weight *int32 `json:"weight,omitempty"`
+}
+
+// PluginConfig holds the arguments of a plugin. The arguments of the plugins other than
+// NodeResourceTopologyMatch are not managed by the deployer, so they are kept as raw JSON.
+type PluginConfig struct {
+	Name string          `json:"name"`
+	Args json.RawMessage `json:"args,omitempty"`
+}
+
+// NodeResourceTopologyMatchArgs are the arguments of the NodeResourceTopologyMatch plugin.
+type NodeResourceTopologyMatchArgs struct {
+	ScoringStrategy          *ScoringStrategy               `json:"scoringStrategy,omitempty"`
+	CacheResyncPeriodSeconds *int64                         `json:"cacheResyncPeriodSeconds,omitempty"`
+	Cache                    *NodeResourceTopologyCacheArgs `json:"cache,omitempty"`
+	DiscardReservedNodes     *bool                          `json:"discardReservedNodes,omitempty"`
+	PreemptionMode           *string                        `json:"preemptionMode,omitempty"`
+}
+
+type ScoringStrategy struct {
+	Type      string         `json:"type,omitempty"`
+	Resources []ResourceSpec `json:"resources,omitempty"`
+}
+
+type ResourceSpec struct {
+	Name   string `json:"name"`
+	Weight int64  `json:"weight,omitempty"`
+}
+
+type NodeResourceTopologyCacheArgs struct {
+	ForeignPodsDetect *string `json:"foreignPodsDetect,omitempty"`
+	ResyncMethod      *string `json:"resyncMethod,omitempty"`
+	InformerMode      *string `json:"informerMode,omitempty"`
+	ResyncScope       *string `json:"resyncScope,omitempty"`
+}
@@ -0,0 +1,299 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package status
+
+import (
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nrtv1alpha2 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha2"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/wait"
+)
+
+const (
+	ComponentNRT = "nrt"
+)
+
+// Component groups the objects deployed for a component of the stack
+type Component struct {
+	Name    string
+	Objects []client.Object
+}
+
+// Item is the health of a single object
+type Item struct {
+	Component string `json:"component"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Healthy   bool   `json:"healthy"`
+	Message   string `json:"message"`
+}
+
+func (it Item) String() string {
+	name := it.Name
+	if it.Namespace != "" {
+		name = it.Namespace + "/" + name
+	}
+	state := "ok"
+	if !it.Healthy {
+		state = "degraded"
+	}
+	return fmt.Sprintf("%s %s %s: %s (%s)", it.Component, it.Kind, name, state, it.Message)
+}
+
+type Report struct {
+	Healthy bool   `json:"healthy"`
+	Items   []Item `json:"items"`
+}
+
+// Degraded returns the unhealthy items of the report
+func (rep Report) Degraded() []Item {
+	var ret []Item
+	for _, it := range rep.Items {
+		if !it.Healthy {
+			ret = append(ret, it)
+		}
+	}
+	return ret
+}
+
+type Options struct {
+	// Workers are the nodes expected to expose a NodeResourceTopology object
+	Workers []corev1.Node
+	// NRTMaxAge is the maximum time since the last update of a NodeResourceTopology
+	// object to be considered fresh. The apiserver does not record the updates which
+	// don't change the object, so an older object is still fresh if the updater pod
+	// on its node is ready. Use 0 to only check presence.
+	NRTMaxAge time.Duration
+	// Now is the reference time to compute the NodeResourceTopology age
+	Now time.Time
+}
+
+// Collect checks the health of the objects of the given components, and
+// the NodeResourceTopology objects of the worker nodes.
+// Only the objects which carry a meaningful state are checked.
+func Collect(env *deployer.Environment, comps []Component, opts Options) (Report, error) {
+	rep := Report{}
+	for _, comp := range comps {
+		for _, obj := range comp.Objects {
+			it, ok, err := checkObject(env, obj)
+			if err != nil {
+				return rep, err
+			}
+			if !ok {
+				continue
+			}
+			it.Component = comp.Name
+			rep.Items = append(rep.Items, it)
+		}
+	}
+
+	nrtItems, err := checkNRTs(env, comps, opts)
+	if err != nil {
+		return rep, err
+	}
+	rep.Items = append(rep.Items, nrtItems...)
+
+	rep.Healthy = len(rep.Degraded()) == 0
+	return rep, nil
+}
+
+func checkObject(env *deployer.Environment, obj client.Object) (Item, bool, error) {
+	var live client.Object
+	var kind string
+	switch obj.(type) {
+	case *apiextensionv1.CustomResourceDefinition:
+		live, kind = &apiextensionv1.CustomResourceDefinition{}, "CustomResourceDefinition"
+	case *appsv1.DaemonSet:
+		live, kind = &appsv1.DaemonSet{}, "DaemonSet"
+	case *appsv1.Deployment:
+		live, kind = &appsv1.Deployment{}, "Deployment"
+	case *corev1.ConfigMap:
+		live, kind = &corev1.ConfigMap{}, "ConfigMap"
+	default:
+		return Item{}, false, nil
+	}
+
+	it := Item{
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+	err := env.Cli.Get(env.Ctx, client.ObjectKeyFromObject(obj), live)
+	if apierrors.IsNotFound(err) {
+		it.Message = "missing"
+		return it, true, nil
+	}
+	if err != nil {
+		return it, true, err
+	}
+
+	switch liveObj := live.(type) {
+	case *apiextensionv1.CustomResourceDefinition:
+		it.Healthy = isCRDEstablished(liveObj)
+		it.Message = "established"
+		if !it.Healthy {
+			it.Message = "not established"
+		}
+	case *appsv1.DaemonSet:
+		it.Healthy = wait.AreDaemonSetPodsReady(&liveObj.Status)
+		it.Message = fmt.Sprintf("%d/%d pods ready", liveObj.Status.NumberReady, liveObj.Status.DesiredNumberScheduled)
+	case *appsv1.Deployment:
+		replicas := int32(1)
+		if liveObj.Spec.Replicas != nil {
+			replicas = *liveObj.Spec.Replicas
+		}
+		it.Healthy = liveObj.Status.AvailableReplicas >= replicas
+		it.Message = fmt.Sprintf("%d/%d replicas available", liveObj.Status.AvailableReplicas, replicas)
+	case *corev1.ConfigMap:
+		it.Healthy = true
+		it.Message = "present"
+	}
+	return it, true, nil
+}
+
+func isCRDEstablished(crd *apiextensionv1.CustomResourceDefinition) bool {
+	for _, cond := range crd.Status.Conditions {
+		if cond.Type == apiextensionv1.Established {
+			return cond.Status == apiextensionv1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func checkNRTs(env *deployer.Environment, comps []Component, opts Options) ([]Item, error) {
+	if len(opts.Workers) == 0 {
+		return []Item{
+			{
+				Component: ComponentNRT,
+				Kind:      "Node",
+				Message:   "no worker nodes found",
+			},
+		}, nil
+	}
+
+	nrtList := nrtv1alpha2.NodeResourceTopologyList{}
+	err := env.Cli.List(env.Ctx, &nrtList)
+	if err != nil {
+		return nil, err
+	}
+	nrtByName := make(map[string]*nrtv1alpha2.NodeResourceTopology)
+	for idx := range nrtList.Items {
+		nrtByName[nrtList.Items[idx].Name] = &nrtList.Items[idx]
+	}
+
+	var updaterReady map[string]bool
+	var items []Item
+	for _, node := range opts.Workers {
+		it := Item{
+			Component: ComponentNRT,
+			Kind:      "NodeResourceTopology",
+			Name:      node.Name,
+		}
+		nrt, ok := nrtByName[node.Name]
+		if !ok {
+			it.Message = "missing"
+			items = append(items, it)
+			continue
+		}
+		age := opts.Now.Sub(LastUpdateTime(nrt)).Truncate(time.Second)
+		it.Healthy = opts.NRTMaxAge == 0 || age <= opts.NRTMaxAge
+		it.Message = fmt.Sprintf("updated %v ago", age)
+		if !it.Healthy {
+			if updaterReady == nil {
+				updaterReady, err = updaterReadyNodes(env, comps)
+				if err != nil {
+					return nil, err
+				}
+			}
+			// the updater rewrites the object periodically, but the apiserver does not record no-op updates
+			it.Healthy = updaterReady[node.Name]
+			if it.Healthy {
+				it.Message = fmt.Sprintf("changed %v ago, updater ready", age)
+			}
+		}
+		items = append(items, it)
+	}
+	return items, nil
+}
+
+// updaterReadyNodes returns the nodes on which a pod of the DaemonSets of the given components is ready.
+func updaterReadyNodes(env *deployer.Environment, comps []Component) (map[string]bool, error) {
+	ret := make(map[string]bool)
+	for _, comp := range comps {
+		for _, obj := range comp.Objects {
+			if _, ok := obj.(*appsv1.DaemonSet); !ok {
+				continue
+			}
+			ds := appsv1.DaemonSet{}
+			err := env.Cli.Get(env.Ctx, client.ObjectKeyFromObject(obj), &ds)
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if ds.Spec.Selector == nil || len(ds.Spec.Selector.MatchLabels) == 0 {
+				continue
+			}
+			podList := corev1.PodList{}
+			err = env.Cli.List(env.Ctx, &podList, client.InNamespace(ds.Namespace), client.MatchingLabels(ds.Spec.Selector.MatchLabels))
+			if err != nil {
+				return nil, err
+			}
+			for idx := range podList.Items {
+				pod := &podList.Items[idx]
+				if pod.Spec.NodeName != "" && isPodReady(pod) {
+					ret[pod.Spec.NodeName] = true
+				}
+			}
+		}
+	}
+	return ret, nil
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// LastUpdateTime returns the time of the most recent write of the object,
+// as recorded by the apiserver in the managed fields. Note the apiserver
+// does not record no-op updates.
+func LastUpdateTime(obj client.Object) time.Time {
+	ret := obj.GetCreationTimestamp().Time
+	for _, mf := range obj.GetManagedFields() {
+		if mf.Time != nil && mf.Time.After(ret) {
+			ret = mf.Time.Time
+		}
+	}
+	return ret
+}
@@ -0,0 +1,232 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	nrtv1alpha2 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha2"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+)
+
+func TestCollect(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	crd := &apiextensionv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "noderesourcetopologies.topology.node.k8s.io"},
+	}
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tas", Name: "rte"},
+	}
+	dp := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tas", Name: "sched"},
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tas", Name: "sched-config"},
+	}
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tas", Name: "rte"},
+	}
+	comps := []Component{
+		{Name: "api", Objects: []client.Object{crd}},
+		{Name: "rte", Objects: []client.Object{sa, ds}},
+		{Name: "sched", Objects: []client.Object{cm, dp}},
+	}
+	workers := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-0"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}},
+	}
+
+	liveCRD := crd.DeepCopy()
+	liveCRD.Status.Conditions = []apiextensionv1.CustomResourceDefinitionCondition{
+		{Type: apiextensionv1.Established, Status: apiextensionv1.ConditionTrue},
+	}
+	liveDS := ds.DeepCopy()
+	liveDS.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"name": "rte"}}
+	liveDS.Status = appsv1.DaemonSetStatus{DesiredNumberScheduled: 2, NumberReady: 2}
+	liveDP := dp.DeepCopy()
+	liveDP.Spec.Replicas = newInt32(2)
+	liveDP.Status = appsv1.DeploymentStatus{AvailableReplicas: 1}
+	nrt := &nrtv1alpha2.NodeResourceTopology{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "worker-0",
+			CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+		},
+	}
+
+	newUpdaterPod := func(nodeName string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tas", Name: "rte-" + nodeName, Labels: map[string]string{"name": "rte"}},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+
+	type testCase struct {
+		name            string
+		nrtMaxAge       time.Duration
+		objs            []client.Object
+		expectedHealthy map[string]bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "presence only",
+			expectedHealthy: map[string]bool{
+				"api CustomResourceDefinition noderesourcetopologies.topology.node.k8s.io": true,
+				"rte DaemonSet tas/rte":             true,
+				"sched ConfigMap tas/sched-config":  false,
+				"sched Deployment tas/sched":        false,
+				"nrt NodeResourceTopology worker-0": true,
+				"nrt NodeResourceTopology worker-1": false,
+			},
+		},
+		{
+			name:      "stale NRT",
+			nrtMaxAge: 5 * time.Minute,
+			expectedHealthy: map[string]bool{
+				"api CustomResourceDefinition noderesourcetopologies.topology.node.k8s.io": true,
+				"rte DaemonSet tas/rte":             true,
+				"sched ConfigMap tas/sched-config":  false,
+				"sched Deployment tas/sched":        false,
+				"nrt NodeResourceTopology worker-0": false,
+				"nrt NodeResourceTopology worker-1": false,
+			},
+		},
+		{
+			name:      "unchanged NRT, updater ready",
+			nrtMaxAge: 5 * time.Minute,
+			objs:      []client.Object{newUpdaterPod("worker-0", corev1.ConditionTrue)},
+			expectedHealthy: map[string]bool{
+				"api CustomResourceDefinition noderesourcetopologies.topology.node.k8s.io": true,
+				"rte DaemonSet tas/rte":             true,
+				"sched ConfigMap tas/sched-config":  false,
+				"sched Deployment tas/sched":        false,
+				"nrt NodeResourceTopology worker-0": true,
+				"nrt NodeResourceTopology worker-1": false,
+			},
+		},
+		{
+			name:      "unchanged NRT, updater not ready",
+			nrtMaxAge: 5 * time.Minute,
+			objs:      []client.Object{newUpdaterPod("worker-0", corev1.ConditionFalse)},
+			expectedHealthy: map[string]bool{
+				"api CustomResourceDefinition noderesourcetopologies.topology.node.k8s.io": true,
+				"rte DaemonSet tas/rte":             true,
+				"sched ConfigMap tas/sched-config":  false,
+				"sched Deployment tas/sched":        false,
+				"nrt NodeResourceTopology worker-0": false,
+				"nrt NodeResourceTopology worker-1": false,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cli := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(liveCRD, liveDS, liveDP, nrt.DeepCopy()).WithObjects(tc.objs...).Build()
+			env := &deployer.Environment{
+				Ctx: context.TODO(),
+				Cli: cli,
+				Log: testr.New(t),
+			}
+			rep, err := Collect(env, comps, Options{
+				Workers:   workers,
+				NRTMaxAge: tc.nrtMaxAge,
+				Now:       now,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if rep.Healthy {
+				t.Errorf("report healthy, expected degraded")
+			}
+			if len(rep.Items) != len(tc.expectedHealthy) {
+				t.Fatalf("items got %d expected %d: %v", len(rep.Items), len(tc.expectedHealthy), rep.Items)
+			}
+			for _, it := range rep.Items {
+				key := it.Component + " " + it.Kind + " " + it.Name
+				if it.Namespace != "" {
+					key = it.Component + " " + it.Kind + " " + it.Namespace + "/" + it.Name
+				}
+				expected, ok := tc.expectedHealthy[key]
+				if !ok {
+					t.Errorf("unexpected item %q", key)
+					continue
+				}
+				if it.Healthy != expected {
+					t.Errorf("item %q healthy got %v expected %v (%s)", key, it.Healthy, expected, it.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestLastUpdateTime(t *testing.T) {
+	created := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	updated := created.Add(10 * time.Minute)
+	nrt := &nrtv1alpha2.NodeResourceTopology{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "worker-0",
+			CreationTimestamp: metav1.NewTime(created),
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "rte", Time: &metav1.Time{Time: created}},
+				{Manager: "rte", Time: &metav1.Time{Time: updated}},
+				{Manager: "kubectl"},
+			},
+		},
+	}
+	got := LastUpdateTime(nrt)
+	if !got.Equal(updated) {
+		t.Errorf("last update time got %v expected %v", got, updated)
+	}
+}
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	sch := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(sch); err != nil {
+		t.Fatalf("cannot setup scheme: %v", err)
+	}
+	if err := apiextensionv1.AddToScheme(sch); err != nil {
+		t.Fatalf("cannot setup scheme: %v", err)
+	}
+	if err := nrtv1alpha2.AddToScheme(sch); err != nil {
+		t.Fatalf("cannot setup scheme: %v", err)
+	}
+	return sch
+}
+
+func newInt32(value int32) *int32 {
+	return &value
+}
@@ -30,18 +30,39 @@ const (
 	ComponentAPIVersion = "API Version"
 )
 
+const (
+	RuleClusterVersion = "cluster-version"
+)
+
 func (vd *Validator) ValidateClusterVersion(cli *discovery.DiscoveryClient) ([]ValidationResult, error) {
 	ver, err := cli.ServerVersion()
 	if err != nil {
 		return nil, err
 	}
 	vd.serverVersion = ver
-	vrs := ValidateClusterVersion(ver.GitVersion)
+	vrs := vd.ruleSet().ValidateCluster(vd.clusterRuleInput())
+	vd.clusterValidated = true
 	vd.results = append(vd.results, vrs...)
 	return vrs, nil
 }
 
+// ValidateClusterVersion runs the cluster rules of the default rule set on the given server version.
 func ValidateClusterVersion(clusterVersion string) []ValidationResult {
+	return DefaultRuleSet().ValidateCluster(RuleInput{ServerVersion: clusterVersion})
+}
+
+func clusterVersionRule() Rule {
+	return NewRule(RuleInfo{
+		ID:          RuleClusterVersion,
+		Description: "the cluster runs at least kubernetes " + ExpectedMinKubeVersion,
+		Severity:    SeverityError,
+		Scope:       ScopeCluster,
+	}, func(in RuleInput) []ValidationResult {
+		return checkClusterVersion(in.ServerVersion)
+	})
+}
+
+func checkClusterVersion(clusterVersion string) []ValidationResult {
 	ok, err := isAPIVersionAtLeast(clusterVersion, ExpectedMinKubeVersion)
 	if err != nil {
 		return []ValidationResult{
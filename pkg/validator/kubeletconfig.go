@@ -17,18 +17,24 @@
 package validator
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/version"
 	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
 
+	"github.com/k8stopologyawareschedwg/deployer/pkg/clientutil"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/kubeletconfig"
 )
 
 const (
 	ComponentConfiguration   = "configuration"
+	ComponentConfigz         = "configz"
 	ComponentFeatureGates    = "feature gates"
 	ComponentCPUManager      = "CPU manager"
 	ComponentMemoryManager   = "memory manager"
@@ -51,130 +57,273 @@ const (
 	kubeMinVersionGetAllocatable = "1.23"
 )
 
+const (
+	RuleCPUManagerPolicy          = "cpu-manager-policy"
+	RuleCPUManagerReconcilePeriod = "cpu-manager-reconcile-period"
+	RuleReservedCPUs              = "reserved-cpus"
+	RuleMemoryManagerPolicy       = "memory-manager-policy"
+	RuleReservedMemory            = "reserved-memory"
+	RuleTopologyManagerPolicy     = "topology-manager-policy"
+)
+
 func (vd *Validator) ValidateClusterConfig(nodes []corev1.Node) ([]ValidationResult, error) {
 	nodeNames := []string{}
 	for _, node := range nodes {
 		nodeNames = append(nodeNames, node.Name)
 	}
 
-	kc := kubeletconfig.NewKubectlFromEnv(vd.Log)
-	if ok, err := kc.IsReady(); !ok {
-		return nil, err
-	}
-
-	kubeConfs, err := kubeletconfig.GetKubeletConfigForNodes(kc, nodeNames, vd.Log)
+	coll, err := vd.collectKubeletConfig(nodeNames)
 	if err != nil {
 		return nil, err
 	}
 
+	vd.numaNodes = vd.collectNUMANodes()
+	vd.recordClusterResults()
+
+	rs := vd.ruleSet()
 	vrs := []ValidationResult{}
-	if len(kubeConfs) == 0 {
-		vrs = append(vrs, ValidationResult{
-			/* no specific nodes: all are missing! */
-			Area: AreaCluster,
-			/* no specific component: there are no nodes at all! */
-			/* no specific Setting: all are missing! */
-			Expected: "worker nodes",
-			Detected: "none",
-		})
+	if len(coll.Configs) == 0 && len(coll.Errors) == 0 {
+		vrs = append(vrs, noWorkerNodesResult())
 	} else {
-		for nodeName, kubeletConf := range kubeConfs {
-			vrs = append(vrs, vd.ValidateNodeKubeletConfig(nodeName, vd.serverVersion, kubeletConf)...)
+		for _, nodeName := range nodeNames {
+			if err, ok := coll.Errors[nodeName]; ok {
+				vrs = append(vrs, ValidateNodeKubeletConfigCollection(nodeName, err))
+				continue
+			}
+			if kubeletConf, ok := coll.Configs[nodeName]; ok {
+				vrs = append(vrs, vd.ValidateNodeKubeletConfig(nodeName, vd.serverVersion, kubeletConf)...)
+			}
 		}
+		in := vd.clusterRuleInput()
+		in.KubeletConfigs = coll.Configs
+		vrs = append(vrs, rs.ValidateNodes(in)...)
 	}
 	vd.results = append(vd.results, vrs...)
 	return vrs, nil
 }
 
-func (vd *Validator) ValidateNodeKubeletConfig(nodeName string, nodeVersion *version.Info, kubeletConf *kubeletconfigv1beta1.KubeletConfiguration) []ValidationResult {
-	vrs := ValidateClusterNodeKubeletConfig(nodeName, nodeVersion, kubeletConf)
-	result := "OK"
-	if len(vrs) > 0 {
-		result = fmt.Sprintf("%d issues found", len(vrs))
+// collectKubeletConfig fetches the kubelet configuration through the apiserver,
+// falling back to `kubectl proxy` if the apiserver can't be used.
+func (vd *Validator) collectKubeletConfig(nodeNames []string) (kubeletconfig.Collection, error) {
+	opts := kubeletconfig.CollectOptions{
+		Concurrency: vd.Concurrency,
+		NodeTimeout: vd.NodeTimeout,
 	}
-	vd.Log.Info("validated", "node", nodeName, "result", result)
-	return vrs
-}
-
-func ValidateClusterNodeKubeletConfig(nodeName string, nodeVersion *version.Info, kubeletConf *kubeletconfigv1beta1.KubeletConfiguration) []ValidationResult {
-	vrs := []ValidationResult{}
+	cs, err := clientutil.NewK8s()
+	if err == nil {
+		var coll kubeletconfig.Collection
+		coll, err = kubeletconfig.CollectFromAPIServer(context.Background(), cs, nodeNames, opts)
+		if err == nil {
+			return coll, nil
+		}
+	}
+	vd.Log.Info("cannot fetch the kubelet configuration through the apiserver, falling back to kubectl", "error", err)
 
-	if kubeletConf == nil {
-		vrs = append(vrs, ValidationResult{
-			Node:      nodeName,
-			Area:      AreaKubelet,
-			Component: ComponentConfiguration,
-			/* no specific Setting: all are missing! */
-			Expected: "any value",
-			Detected: "no configuration",
-		})
-		return vrs
+	kc := kubeletconfig.NewKubectlFromEnv(vd.Log)
+	if ok, err := kc.IsReady(); !ok {
+		return kubeletconfig.Collection{}, err
 	}
+	return kubeletconfig.CollectFromKubectl(context.Background(), kc, nodeNames, opts, vd.Log)
+}
 
-	if kubeletConf.CPUManagerPolicy != ExpectedCPUManagerPolicy {
-		vrs = append(vrs, ValidationResult{
-			Node:      nodeName,
-			Area:      AreaKubelet,
-			Component: ComponentCPUManager,
-			Setting:   "policy",
-			Expected:  ExpectedCPUManagerPolicy,
-			Detected:  kubeletConf.CPUManagerPolicy,
-		})
+// ValidateNodeKubeletConfigCollection reports the failure to fetch the kubelet configuration of a node,
+// so an unreachable node is told apart from a misconfigured one.
+func ValidateNodeKubeletConfigCollection(nodeName string, err error) ValidationResult {
+	return ValidationResult{
+		Node:      nodeName,
+		Area:      AreaNode,
+		Component: ComponentConfigz,
+		Expected:  "reachable",
+		Detected:  collectionErrorReason(err),
+		Severity:  SeverityError,
 	}
+}
 
-	if kubeletConf.CPUManagerReconcilePeriod.Duration < CPUManagerReconcilePeriodMin ||
-		kubeletConf.CPUManagerReconcilePeriod.Duration > CPUManagerReconcilePeriodMax {
-		vrs = append(vrs, ValidationResult{
-			Node:      nodeName,
-			Area:      AreaKubelet,
-			Component: ComponentCPUManager,
-			Setting:   "reconcile period",
-			Expected:  fmt.Sprintf("in range [%v, %v]", CPUManagerReconcilePeriodMin, CPUManagerReconcilePeriodMax),
-			Detected:  fmt.Sprintf("%v", kubeletConf.CPUManagerReconcilePeriod.Duration),
-		})
+func collectionErrorReason(err error) string {
+	if errors.Is(err, kubeletconfig.ErrMalformedConfigz) {
+		return "malformed"
 	}
-	if kubeletConf.ReservedSystemCPUs == "" {
-		vrs = append(vrs, ValidationResult{
-			Node:      nodeName,
-			Area:      AreaKubelet,
-			Component: ComponentConfiguration,
-			Setting:   "CPU",
-			Expected:  "reserved some CPU cores",
-			Detected:  "no reserved CPU cores",
-		})
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "unreachable: timeout"
 	}
+	var apiStatus apierrors.APIStatus
+	if errors.As(err, &apiStatus) {
+		return fmt.Sprintf("unreachable: %d", apiStatus.Status().Code)
+	}
+	var statusErr *kubeletconfig.StatusError
+	if errors.As(err, &statusErr) {
+		return fmt.Sprintf("unreachable: %d", statusErr.Code)
+	}
+	return fmt.Sprintf("unreachable: %v", err)
+}
 
-	if kubeletConf.MemoryManagerPolicy != ExpectedMemoryManagerPolicy {
-		vrs = append(vrs, ValidationResult{
-			Node:      nodeName,
-			Area:      AreaKubelet,
-			Component: ComponentMemoryManager,
-			Setting:   "policy",
-			Expected:  ExpectedMemoryManagerPolicy,
-			Detected:  kubeletConf.MemoryManagerPolicy,
-		})
+func (vd *Validator) ValidateNodeKubeletConfig(nodeName string, nodeVersion *version.Info, kubeletConf *kubeletconfigv1beta1.KubeletConfiguration) []ValidationResult {
+	in := vd.clusterRuleInput()
+	in.NodeName = nodeName
+	in.NodeVersion = nodeVersion
+	in.KubeletConfig = kubeletConf
+	in.NUMANodes = vd.numaNodes[nodeName]
+	return vd.logNodeResults(nodeName, vd.ruleSet().ValidateNode(in))
+}
+
+func (vd *Validator) logNodeResults(nodeName string, vrs []ValidationResult) []ValidationResult {
+	result := "OK"
+	if len(vrs) > 0 {
+		result = fmt.Sprintf("%d issues found", len(vrs))
 	}
+	vd.Log.Info("validated", "node", nodeName, "result", result)
+	return vrs
+}
+
+// ValidateKubeletConfigs runs offline the default rule set on the server version, unless it is empty,
+// and on the kubelet configuration of the given nodes, keyed by node name.
+func ValidateKubeletConfigs(serverVersion string, kubeletConfs map[string]*kubeletconfigv1beta1.KubeletConfiguration) []ValidationResult {
+	return DefaultRuleSet().ValidateKubeletConfigs(platform.Unknown, serverVersion, kubeletConfs)
+}
 
-	if len(kubeletConf.ReservedMemory) == 0 {
-		vrs = append(vrs, ValidationResult{
-			Node:      nodeName,
-			Area:      AreaKubelet,
-			Component: ComponentConfiguration,
-			Setting:   "memory",
-			Expected:  "reserved memory blocks",
-			Detected:  "no reserved memory blocks",
-		})
+func noWorkerNodesResult() ValidationResult {
+	return ValidationResult{
+		/* no specific nodes: all are missing! */
+		Area: AreaCluster,
+		/* no specific component: there are no nodes at all! */
+		/* no specific Setting: all are missing! */
+		Expected: "worker nodes",
+		Detected: "none",
+		Severity: SeverityError,
 	}
+}
 
-	if kubeletConf.TopologyManagerPolicy != ExpectedTopologyManagerPolicy {
-		vrs = append(vrs, ValidationResult{
-			Node:      nodeName,
-			Area:      AreaKubelet,
-			Component: ComponentTopologyManager,
-			Setting:   "policy",
-			Expected:  ExpectedTopologyManagerPolicy,
-			Detected:  kubeletConf.TopologyManagerPolicy,
-		})
+// ValidateClusterNodeKubeletConfig runs the node rules of the default rule set on the kubelet configuration of a node.
+func ValidateClusterNodeKubeletConfig(nodeName string, nodeVersion *version.Info, kubeletConf *kubeletconfigv1beta1.KubeletConfiguration) []ValidationResult {
+	return DefaultRuleSet().ValidateNode(RuleInput{
+		NodeName:      nodeName,
+		NodeVersion:   nodeVersion,
+		KubeletConfig: kubeletConf,
+	})
+}
+
+func kubeletConfigRules() []Rule {
+	return []Rule{
+		NewRule(RuleInfo{
+			ID:          RuleCPUManagerPolicy,
+			Description: "the CPU manager policy is " + ExpectedCPUManagerPolicy,
+			Severity:    SeverityError,
+			Scope:       ScopeNode,
+		}, func(in RuleInput) []ValidationResult {
+			if in.KubeletConfig.CPUManagerPolicy == ExpectedCPUManagerPolicy {
+				return nil
+			}
+			return []ValidationResult{
+				{
+					Node:      in.NodeName,
+					Area:      AreaKubelet,
+					Component: ComponentCPUManager,
+					Setting:   "policy",
+					Expected:  ExpectedCPUManagerPolicy,
+					Detected:  in.KubeletConfig.CPUManagerPolicy,
+				},
+			}
+		}),
+		NewRule(RuleInfo{
+			ID:          RuleCPUManagerReconcilePeriod,
+			Description: fmt.Sprintf("the CPU manager reconcile period is in range [%v, %v]", CPUManagerReconcilePeriodMin, CPUManagerReconcilePeriodMax),
+			Severity:    SeverityError,
+			Scope:       ScopeNode,
+		}, func(in RuleInput) []ValidationResult {
+			period := in.KubeletConfig.CPUManagerReconcilePeriod.Duration
+			if period >= CPUManagerReconcilePeriodMin && period <= CPUManagerReconcilePeriodMax {
+				return nil
+			}
+			return []ValidationResult{
+				{
+					Node:      in.NodeName,
+					Area:      AreaKubelet,
+					Component: ComponentCPUManager,
+					Setting:   "reconcile period",
+					Expected:  fmt.Sprintf("in range [%v, %v]", CPUManagerReconcilePeriodMin, CPUManagerReconcilePeriodMax),
+					Detected:  fmt.Sprintf("%v", period),
+				},
+			}
+		}),
+		NewRule(RuleInfo{
+			ID:          RuleReservedCPUs,
+			Description: "some CPU cores are reserved for the system",
+			Severity:    SeverityError,
+			Scope:       ScopeNode,
+		}, func(in RuleInput) []ValidationResult {
+			if in.KubeletConfig.ReservedSystemCPUs != "" {
+				return nil
+			}
+			return []ValidationResult{
+				{
+					Node:      in.NodeName,
+					Area:      AreaKubelet,
+					Component: ComponentConfiguration,
+					Setting:   "CPU",
+					Expected:  "reserved some CPU cores",
+					Detected:  "no reserved CPU cores",
+				},
+			}
+		}),
+		NewRule(RuleInfo{
+			ID:          RuleMemoryManagerPolicy,
+			Description: "the memory manager policy is " + ExpectedMemoryManagerPolicy,
+			Severity:    SeverityError,
+			Scope:       ScopeNode,
+		}, func(in RuleInput) []ValidationResult {
+			if in.KubeletConfig.MemoryManagerPolicy == ExpectedMemoryManagerPolicy {
+				return nil
+			}
+			return []ValidationResult{
+				{
+					Node:      in.NodeName,
+					Area:      AreaKubelet,
+					Component: ComponentMemoryManager,
+					Setting:   "policy",
+					Expected:  ExpectedMemoryManagerPolicy,
+					Detected:  in.KubeletConfig.MemoryManagerPolicy,
+				},
+			}
+		}),
+		NewRule(RuleInfo{
+			ID:          RuleReservedMemory,
+			Description: "some memory blocks are reserved for the system",
+			Severity:    SeverityError,
+			Scope:       ScopeNode,
+		}, func(in RuleInput) []ValidationResult {
+			if len(in.KubeletConfig.ReservedMemory) > 0 {
+				return nil
+			}
+			return []ValidationResult{
+				{
+					Node:      in.NodeName,
+					Area:      AreaKubelet,
+					Component: ComponentConfiguration,
+					Setting:   "memory",
+					Expected:  "reserved memory blocks",
+					Detected:  "no reserved memory blocks",
+				},
+			}
+		}),
+		NewRule(RuleInfo{
+			ID:          RuleTopologyManagerPolicy,
+			Description: "the topology manager policy is " + ExpectedTopologyManagerPolicy,
+			Severity:    SeverityError,
+			Scope:       ScopeNode,
+		}, func(in RuleInput) []ValidationResult {
+			if in.KubeletConfig.TopologyManagerPolicy == ExpectedTopologyManagerPolicy {
+				return nil
+			}
+			return []ValidationResult{
+				{
+					Node:      in.NodeName,
+					Area:      AreaKubelet,
+					Component: ComponentTopologyManager,
+					Setting:   "policy",
+					Expected:  ExpectedTopologyManagerPolicy,
+					Detected:  in.KubeletConfig.TopologyManagerPolicy,
+				},
+			}
+		}),
 	}
-	return vrs
 }
@@ -17,15 +17,22 @@
 package validator
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/go-logr/stdr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/version"
 	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/kubeletconfig"
 )
 
 func TestKubeletValidations(t *testing.T) {
@@ -257,6 +264,146 @@ func TestKubeletValidations(t *testing.T) {
 	}
 }
 
+func TestValidateKubeletConfigs(t *testing.T) {
+	correct := &kubeletconfigv1beta1.KubeletConfiguration{
+		CPUManagerPolicy: ExpectedCPUManagerPolicy,
+		CPUManagerReconcilePeriod: metav1.Duration{
+			Duration: 5 * time.Second,
+		},
+		MemoryManagerPolicy: ExpectedMemoryManagerPolicy,
+		ReservedMemory: []kubeletconfigv1beta1.MemoryReservation{
+			{
+				NumaNode: 1,
+			},
+		},
+		ReservedSystemCPUs:    "0,1",
+		TopologyManagerPolicy: ExpectedTopologyManagerPolicy,
+	}
+	wrongTopologyPolicy := correct.DeepCopy()
+	wrongTopologyPolicy.TopologyManagerPolicy = "restricted"
+
+	testCases := []struct {
+		name          string
+		serverVersion string
+		kubeletConfs  map[string]*kubeletconfigv1beta1.KubeletConfiguration
+		expected      []ValidationResult
+	}{
+		{
+			name:     "no nodes",
+			expected: []ValidationResult{{Area: AreaCluster}},
+		},
+		{
+			name:          "old server",
+			serverVersion: "v1.20.4",
+			kubeletConfs: map[string]*kubeletconfigv1beta1.KubeletConfiguration{
+				"worker-0": correct,
+			},
+			expected: []ValidationResult{
+				{Area: AreaCluster, Component: ComponentAPIVersion},
+				{Node: "worker-0", Area: AreaKubelet, Component: ComponentFeatureGates, Setting: "MemoryManager"},
+				{Node: "worker-0", Area: AreaKubelet, Component: ComponentFeatureGates, Setting: "KubeletPodResourcesGetAllocatable"},
+			},
+		},
+		{
+			name:          "misconfigured node",
+			serverVersion: "v1.30.2",
+			kubeletConfs: map[string]*kubeletconfigv1beta1.KubeletConfiguration{
+				"worker-0": correct,
+				"worker-1": wrongTopologyPolicy,
+			},
+			expected: []ValidationResult{{Node: "worker-1", Area: AreaKubelet, Component: ComponentTopologyManager, Setting: "policy"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ValidateKubeletConfigs(tc.serverVersion, tc.kubeletConfs)
+			if !matchValidationResults(tc.expected, got) {
+				t.Errorf("validation failed:\nexpected=%#v\ngot=%#v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestValidateMinimalKubeletConfigFile(t *testing.T) {
+	// the settings not given here are left to the kubelet defaults, which the checks must accept
+	data := `apiVersion: kubelet.config.k8s.io/v1beta1
+kind: KubeletConfiguration
+cpuManagerPolicy: static
+memoryManagerPolicy: Static
+reservedMemory:
+- numaNode: 0
+  limits:
+    memory: 1Gi
+reservedSystemCPUs: "0,1"
+topologyManagerPolicy: single-numa-node
+`
+	conf, err := kubeletconfig.DecodeKubeletConfig([]byte(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := ValidateKubeletConfigs("v1.30.2", map[string]*kubeletconfigv1beta1.KubeletConfiguration{"worker-0": conf})
+	if len(got) != 0 {
+		t.Errorf("unexpected validation results: %#v", got)
+	}
+}
+
+func TestValidateNodeKubeletConfigCollection(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "apiserver status",
+			err:      apierrors.NewServiceUnavailable("no endpoints available"),
+			expected: "unreachable: 503",
+		},
+		{
+			name:     "proxy status",
+			err:      &kubeletconfig.StatusError{Code: 502},
+			expected: "unreachable: 502",
+		},
+		{
+			name:     "timeout",
+			err:      fmt.Errorf("get configz: %w", context.DeadlineExceeded),
+			expected: "unreachable: timeout",
+		},
+		{
+			name:     "malformed",
+			err:      fmt.Errorf("%w: unexpected end of JSON input", kubeletconfig.ErrMalformedConfigz),
+			expected: "malformed",
+		},
+		{
+			name:     "not found",
+			err:      apierrors.NewNotFound(schema.GroupResource{Resource: "nodes"}, "worker-0"),
+			expected: "unreachable: 404",
+		},
+		{
+			name:     "other",
+			err:      errors.New("connection refused"),
+			expected: "unreachable: connection refused",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vr := ValidateNodeKubeletConfigCollection("worker-0", tc.err)
+			expected := ValidationResult{
+				Node:      "worker-0",
+				Area:      AreaNode,
+				Component: ComponentConfigz,
+				Expected:  "reachable",
+				Detected:  tc.expected,
+				Severity:  SeverityError,
+			}
+			if vr != expected {
+				t.Errorf("got %+v expected %+v", vr, expected)
+			}
+		})
+	}
+}
+
 func matchValidationResults(expected, got []ValidationResult) bool {
 	if len(expected) != len(got) {
 		return false
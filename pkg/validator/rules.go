@@ -0,0 +1,390 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/version"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+func ParseSeverity(sev string) (Severity, error) {
+	switch Severity(sev) {
+	case SeverityError, SeverityWarning, SeverityInfo:
+		return Severity(sev), nil
+	}
+	return "", fmt.Errorf("unknown severity %q", sev)
+}
+
+type Scope string
+
+const (
+	// ScopeCluster rules run once per validation
+	ScopeCluster Scope = "cluster"
+	// ScopeNode rules run once per node, on its kubelet configuration
+	ScopeNode Scope = "node"
+	// ScopeNodes rules run once per validation, comparing the kubelet configuration of all the nodes
+	ScopeNodes Scope = "nodes"
+)
+
+type RuleInfo struct {
+	ID          string
+	Description string
+	Severity    Severity
+	Scope       Scope
+	// Platforms the rule applies to. Empty means all the platforms.
+	Platforms []platform.Platform
+	// MinKubeVersion is the first server version the rule applies to. Empty means any.
+	MinKubeVersion string
+	// MaxKubeVersion is the first server version the rule no longer applies to. Empty means any.
+	MaxKubeVersion string
+}
+
+// AppliesTo tells if the rule is meaningful on the given platform and server version.
+// Unknown (or empty) platform and server version are assumed to match.
+func (info RuleInfo) AppliesTo(plat platform.Platform, serverVersion string) bool {
+	if plat != "" && plat != platform.Unknown && len(info.Platforms) > 0 {
+		found := false
+		for _, rulePlat := range info.Platforms {
+			if rulePlat == plat {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if serverVersion == "" {
+		return true
+	}
+	if info.MinKubeVersion != "" {
+		// malformed versions are reported by the cluster version rule, don't hide the other findings
+		if ok, err := isAPIVersionAtLeast(serverVersion, info.MinKubeVersion); err == nil && !ok {
+			return false
+		}
+	}
+	if info.MaxKubeVersion != "" {
+		if ok, err := isAPIVersionAtLeast(serverVersion, info.MaxKubeVersion); err == nil && ok {
+			return false
+		}
+	}
+	return true
+}
+
+// RuleInput is the data the rules check. The node fields are set only for the ScopeNode rules,
+// KubeletConfigs only for the ScopeNodes rules.
+type RuleInput struct {
+	Platform      platform.Platform
+	ServerVersion string
+	NodeName      string
+	NodeVersion   *version.Info
+	KubeletConfig *kubeletconfigv1beta1.KubeletConfiguration
+	// NUMANodes are the IDs of the NUMA nodes of the node. Empty if unknown.
+	NUMANodes []int
+	// KubeletConfigs are the kubelet configurations of all the nodes, keyed by node name
+	KubeletConfigs map[string]*kubeletconfigv1beta1.KubeletConfiguration
+}
+
+// KubeVersion returns the kubernetes version of the node, if known, or of the server otherwise.
+func (in RuleInput) KubeVersion() string {
+	if in.NodeVersion != nil && in.NodeVersion.GitVersion != "" {
+		return in.NodeVersion.GitVersion
+	}
+	return in.ServerVersion
+}
+
+// Rule is a single validation check. The validation fills Rule and Severity of the returned results.
+type Rule interface {
+	Info() RuleInfo
+	Check(in RuleInput) []ValidationResult
+}
+
+type funcRule struct {
+	info  RuleInfo
+	check func(in RuleInput) []ValidationResult
+}
+
+func (fr funcRule) Info() RuleInfo {
+	return fr.info
+}
+
+func (fr funcRule) Check(in RuleInput) []ValidationResult {
+	return fr.check(in)
+}
+
+// NewRule creates a Rule from its description and its check function.
+func NewRule(info RuleInfo, check func(in RuleInput) []ValidationResult) Rule {
+	return funcRule{
+		info:  info,
+		check: check,
+	}
+}
+
+type Registry struct {
+	lock  sync.RWMutex
+	rules []Rule
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (reg *Registry) Register(rule Rule) error {
+	info := rule.Info()
+	if info.ID == "" {
+		return fmt.Errorf("rule without ID")
+	}
+	if _, err := ParseSeverity(string(info.Severity)); err != nil {
+		return fmt.Errorf("rule %q: %w", info.ID, err)
+	}
+	if info.Scope != ScopeCluster && info.Scope != ScopeNode && info.Scope != ScopeNodes {
+		return fmt.Errorf("rule %q: unknown scope %q", info.ID, info.Scope)
+	}
+
+	reg.lock.Lock()
+	defer reg.lock.Unlock()
+	for _, regRule := range reg.rules {
+		if regRule.Info().ID == info.ID {
+			return fmt.Errorf("rule %q already registered", info.ID)
+		}
+	}
+	reg.rules = append(reg.rules, rule)
+	return nil
+}
+
+// Rules returns the registered rules, in registration order.
+func (reg *Registry) Rules() []Rule {
+	reg.lock.RLock()
+	defer reg.lock.RUnlock()
+	return append([]Rule{}, reg.rules...)
+}
+
+type Selection struct {
+	// Enable restricts the validation to these rules, unless empty
+	Enable []string
+	// Disable skips these rules
+	Disable []string
+	// Severities overrides the severity of the rules
+	Severities map[string]Severity
+}
+
+// Select returns the rules picked by the given selection. Referencing unregistered rules is an error.
+func (reg *Registry) Select(sel Selection) (*RuleSet, error) {
+	rules := reg.Rules()
+	known := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		known[rule.Info().ID] = true
+	}
+	enabled, err := ruleIDSet(known, sel.Enable)
+	if err != nil {
+		return nil, err
+	}
+	disabled, err := ruleIDSet(known, sel.Disable)
+	if err != nil {
+		return nil, err
+	}
+	rs := &RuleSet{
+		severities: make(map[string]Severity, len(sel.Severities)),
+	}
+	for id, sev := range sel.Severities {
+		if !known[id] {
+			return nil, fmt.Errorf("unknown rule %q", id)
+		}
+		if _, err := ParseSeverity(string(sev)); err != nil {
+			return nil, fmt.Errorf("rule %q: %w", id, err)
+		}
+		rs.severities[id] = sev
+	}
+	for _, rule := range rules {
+		id := rule.Info().ID
+		if len(enabled) > 0 && !enabled[id] {
+			continue
+		}
+		if disabled[id] {
+			continue
+		}
+		rs.rules = append(rs.rules, rule)
+	}
+	return rs, nil
+}
+
+func ruleIDSet(known map[string]bool, ids []string) (map[string]bool, error) {
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !known[id] {
+			return nil, fmt.Errorf("unknown rule %q", id)
+		}
+		set[id] = true
+	}
+	return set, nil
+}
+
+var defaultRegistry = newBuiltinRegistry()
+
+func builtinRules() []Rule {
+	rules := append([]Rule{clusterVersionRule()}, kubeletConfigRules()...)
+	return append(rules, topologyRules()...)
+}
+
+func newBuiltinRegistry() *Registry {
+	reg := NewRegistry()
+	for _, rule := range builtinRules() {
+		if err := reg.Register(rule); err != nil {
+			panic(err) // programming error
+		}
+	}
+	return reg
+}
+
+// DefaultRegistry returns the registry holding the built-in rules.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// Register adds a rule to the default registry, to be run by all the validations.
+func Register(rule Rule) error {
+	return defaultRegistry.Register(rule)
+}
+
+// DefaultRuleSet returns all the rules in the default registry, with their own severity.
+func DefaultRuleSet() *RuleSet {
+	rs, _ := defaultRegistry.Select(Selection{}) // can't fail: no rules referenced
+	return rs
+}
+
+// RuleSet is the set of rules a validation runs.
+type RuleSet struct {
+	rules      []Rule
+	severities map[string]Severity
+}
+
+func (rs *RuleSet) Rules() []Rule {
+	return append([]Rule{}, rs.rules...)
+}
+
+// Severity returns the severity the rule findings are reported with.
+func (rs *RuleSet) Severity(info RuleInfo) Severity {
+	if sev, ok := rs.severities[info.ID]; ok {
+		return sev
+	}
+	return info.Severity
+}
+
+// ValidateCluster runs the ScopeCluster rules.
+func (rs *RuleSet) ValidateCluster(in RuleInput) []ValidationResult {
+	return rs.run(ScopeCluster, in)
+}
+
+// ValidateNode runs the ScopeNode rules. A missing kubelet configuration is reported
+// as error, because no rule can check it.
+func (rs *RuleSet) ValidateNode(in RuleInput) []ValidationResult {
+	if in.KubeletConfig == nil {
+		return []ValidationResult{
+			{
+				Node:      in.NodeName,
+				Area:      AreaKubelet,
+				Component: ComponentConfiguration,
+				/* no specific Setting: all are missing! */
+				Expected: "any value",
+				Detected: "no configuration",
+				Severity: SeverityError,
+			},
+		}
+	}
+	return rs.run(ScopeNode, in)
+}
+
+// ValidateNodes runs the ScopeNodes rules.
+func (rs *RuleSet) ValidateNodes(in RuleInput) []ValidationResult {
+	return rs.run(ScopeNodes, in)
+}
+
+// ValidateKubeletConfigs runs offline the cluster rules on the server version, unless it is empty,
+// and the node rules on the kubelet configuration of the given nodes, keyed by node name.
+func (rs *RuleSet) ValidateKubeletConfigs(plat platform.Platform, serverVersion string, kubeletConfs map[string]*kubeletconfigv1beta1.KubeletConfiguration) []ValidationResult {
+	vrs := []ValidationResult{}
+	var nodeVersion *version.Info
+	if serverVersion != "" {
+		vrs = append(vrs, rs.ValidateCluster(RuleInput{Platform: plat, ServerVersion: serverVersion})...)
+		nodeVersion = &version.Info{GitVersion: serverVersion}
+	}
+	if len(kubeletConfs) == 0 {
+		return append(vrs, noWorkerNodesResult())
+	}
+
+	nodeNames := make([]string, 0, len(kubeletConfs))
+	for nodeName := range kubeletConfs {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	sort.Strings(nodeNames)
+	for _, nodeName := range nodeNames {
+		vrs = append(vrs, rs.ValidateNode(RuleInput{
+			Platform:      plat,
+			ServerVersion: serverVersion,
+			NodeName:      nodeName,
+			NodeVersion:   nodeVersion,
+			KubeletConfig: kubeletConfs[nodeName],
+		})...)
+	}
+	vrs = append(vrs, rs.ValidateNodes(RuleInput{
+		Platform:       plat,
+		ServerVersion:  serverVersion,
+		KubeletConfigs: kubeletConfs,
+	})...)
+	return vrs
+}
+
+func (rs *RuleSet) run(scope Scope, in RuleInput) []ValidationResult {
+	vrs := []ValidationResult{}
+	for _, rule := range rs.rules {
+		info := rule.Info()
+		if info.Scope != scope || !info.AppliesTo(in.Platform, in.ServerVersion) {
+			continue
+		}
+		sev := rs.Severity(info)
+		for _, vr := range rule.Check(in) {
+			vr.Rule = info.ID
+			vr.Severity = sev
+			vrs = append(vrs, vr)
+		}
+	}
+	return vrs
+}
+
+// HasErrors tells if any of the results has error severity.
+func HasErrors(vrs []ValidationResult) bool {
+	for _, vr := range vrs {
+		if vr.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,261 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package validator
+
+import (
+	"testing"
+
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+)
+
+func TestRegistryRegister(t *testing.T) {
+	check := func(in RuleInput) []ValidationResult { return nil }
+
+	testCases := []struct {
+		name        string
+		info        RuleInfo
+		expectedErr bool
+	}{
+		{
+			name: "valid",
+			info: RuleInfo{ID: "custom", Severity: SeverityWarning, Scope: ScopeNode},
+		},
+		{
+			name:        "duplicate",
+			info:        RuleInfo{ID: RuleReservedMemory, Severity: SeverityWarning, Scope: ScopeNode},
+			expectedErr: true,
+		},
+		{
+			name:        "missing ID",
+			info:        RuleInfo{Severity: SeverityWarning, Scope: ScopeNode},
+			expectedErr: true,
+		},
+		{
+			name:        "unknown severity",
+			info:        RuleInfo{ID: "custom", Severity: "fatal", Scope: ScopeNode},
+			expectedErr: true,
+		},
+		{
+			name:        "unknown scope",
+			info:        RuleInfo{ID: "custom", Severity: SeverityInfo, Scope: "pod"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reg := newBuiltinRegistry()
+			err := reg.Register(NewRule(tc.info, check))
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			expectedLen := len(builtinRules())
+			if !tc.expectedErr {
+				expectedLen++
+			}
+			if got := len(reg.Rules()); got != expectedLen {
+				t.Errorf("got %d rules expected %d", got, expectedLen)
+			}
+		})
+	}
+}
+
+func TestRegistrySelect(t *testing.T) {
+	testCases := []struct {
+		name        string
+		sel         Selection
+		expectedIDs []string
+		expectedErr bool
+	}{
+		{
+			name: "all",
+			expectedIDs: []string{
+				RuleClusterVersion,
+				RuleCPUManagerPolicy,
+				RuleCPUManagerReconcilePeriod,
+				RuleReservedCPUs,
+				RuleMemoryManagerPolicy,
+				RuleReservedMemory,
+				RuleTopologyManagerPolicy,
+				RuleTopologyManagerScope,
+				RuleTopologyManagerScopeConsistency,
+				RuleTopologyManagerPolicyOptions,
+				RuleCPUManagerPolicyOptions,
+				RuleFeatureGates,
+				RuleReservedMemoryNUMACoverage,
+			},
+		},
+		{
+			name:        "enable",
+			sel:         Selection{Enable: []string{RuleTopologyManagerPolicy, RuleClusterVersion}},
+			expectedIDs: []string{RuleClusterVersion, RuleTopologyManagerPolicy},
+		},
+		{
+			name:        "enable and disable",
+			sel:         Selection{Enable: []string{RuleTopologyManagerPolicy, RuleClusterVersion}, Disable: []string{RuleClusterVersion}},
+			expectedIDs: []string{RuleTopologyManagerPolicy},
+		},
+		{
+			name: "disable",
+			sel:  Selection{Disable: []string{RuleClusterVersion, RuleReservedMemory}},
+			expectedIDs: []string{
+				RuleCPUManagerPolicy,
+				RuleCPUManagerReconcilePeriod,
+				RuleReservedCPUs,
+				RuleMemoryManagerPolicy,
+				RuleTopologyManagerPolicy,
+				RuleTopologyManagerScope,
+				RuleTopologyManagerScopeConsistency,
+				RuleTopologyManagerPolicyOptions,
+				RuleCPUManagerPolicyOptions,
+				RuleFeatureGates,
+				RuleReservedMemoryNUMACoverage,
+			},
+		},
+		{
+			name:        "unknown enabled",
+			sel:         Selection{Enable: []string{"foobar"}},
+			expectedErr: true,
+		},
+		{
+			name:        "unknown disabled",
+			sel:         Selection{Disable: []string{"foobar"}},
+			expectedErr: true,
+		},
+		{
+			name:        "unknown severity override",
+			sel:         Selection{Severities: map[string]Severity{"foobar": SeverityInfo}},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid severity override",
+			sel:         Selection{Severities: map[string]Severity{RuleReservedMemory: "fatal"}},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rs, err := newBuiltinRegistry().Select(tc.sel)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expectedErr {
+				return
+			}
+			rules := rs.Rules()
+			if len(rules) != len(tc.expectedIDs) {
+				t.Fatalf("got %d rules expected %v", len(rules), tc.expectedIDs)
+			}
+			for idx, rule := range rules {
+				if id := rule.Info().ID; id != tc.expectedIDs[idx] {
+					t.Errorf("rule #%d: got %q expected %q", idx, id, tc.expectedIDs[idx])
+				}
+			}
+		})
+	}
+}
+
+func TestRuleSetSeverity(t *testing.T) {
+	rs, err := newBuiltinRegistry().Select(Selection{
+		Enable:     []string{RuleReservedMemory, RuleTopologyManagerPolicy},
+		Severities: map[string]Severity{RuleReservedMemory: SeverityWarning},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	vrs := rs.ValidateNode(RuleInput{
+		NodeName:      "worker-0",
+		KubeletConfig: &kubeletconfigv1beta1.KubeletConfiguration{},
+	})
+	expected := map[string]Severity{
+		RuleReservedMemory:        SeverityWarning,
+		RuleTopologyManagerPolicy: SeverityError,
+	}
+	if len(vrs) != len(expected) {
+		t.Fatalf("unexpected results: %#v", vrs)
+	}
+	for _, vr := range vrs {
+		if vr.Severity != expected[vr.Rule] {
+			t.Errorf("rule %q: got severity %q expected %q", vr.Rule, vr.Severity, expected[vr.Rule])
+		}
+	}
+	if !HasErrors(vrs) {
+		t.Errorf("expected errors in %#v", vrs)
+	}
+}
+
+func TestRuleSetSkipsNotApplicableRules(t *testing.T) {
+	reg := NewRegistry()
+	for _, info := range []RuleInfo{
+		{ID: "any", Severity: SeverityInfo, Scope: ScopeCluster},
+		{ID: "openshift", Severity: SeverityInfo, Scope: ScopeCluster, Platforms: []platform.Platform{platform.OpenShift}},
+		{ID: "since-1.28", Severity: SeverityInfo, Scope: ScopeCluster, MinKubeVersion: "1.28"},
+		{ID: "until-1.28", Severity: SeverityInfo, Scope: ScopeCluster, MaxKubeVersion: "1.28"},
+	} {
+		if err := reg.Register(NewRule(info, func(in RuleInput) []ValidationResult {
+			return []ValidationResult{{Area: AreaCluster}}
+		})); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	rs, err := reg.Select(Selection{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name          string
+		plat          platform.Platform
+		serverVersion string
+		expectedRules []string
+	}{
+		{
+			name:          "unknown",
+			expectedRules: []string{"any", "openshift", "since-1.28", "until-1.28"},
+		},
+		{
+			name:          "kubernetes old",
+			plat:          platform.Kubernetes,
+			serverVersion: "v1.27.3",
+			expectedRules: []string{"any", "until-1.28"},
+		},
+		{
+			name:          "openshift new",
+			plat:          platform.OpenShift,
+			serverVersion: "v1.28.0",
+			expectedRules: []string{"any", "openshift", "since-1.28"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vrs := rs.ValidateCluster(RuleInput{Platform: tc.plat, ServerVersion: tc.serverVersion})
+			if len(vrs) != len(tc.expectedRules) {
+				t.Fatalf("got %#v expected rules %v", vrs, tc.expectedRules)
+			}
+			for idx, vr := range vrs {
+				if vr.Rule != tc.expectedRules[idx] {
+					t.Errorf("result #%d: got rule %q expected %q", idx, vr.Rule, tc.expectedRules[idx])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,426 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+
+	nrtv1alpha2 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha2"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/clientutil"
+)
+
+const (
+	RuleTopologyManagerScope            = "topology-manager-scope"
+	RuleTopologyManagerScopeConsistency = "topology-manager-scope-consistency"
+	RuleTopologyManagerPolicyOptions    = "topology-manager-policy-options"
+	RuleCPUManagerPolicyOptions         = "cpu-manager-policy-options"
+	RuleFeatureGates                    = "feature-gates"
+	RuleReservedMemoryNUMACoverage      = "reserved-memory-numa-coverage"
+)
+
+const (
+	TopologyManagerScopeContainer = kubeletconfigv1beta1.ContainerTopologyManagerScope
+	TopologyManagerScopePod       = kubeletconfigv1beta1.PodTopologyManagerScope
+)
+
+// maturity tells the first kubernetes version of each stage of a feature. Empty means not reached (yet).
+type maturity struct {
+	Alpha string
+	Beta  string
+	GA    string
+}
+
+type stage int
+
+const (
+	stageUnknown stage = iota
+	stageUnavailable
+	stageAlpha
+	stageBeta
+	stageGA
+)
+
+// stageAt returns the stage of the feature at the given kubernetes version, or stageUnknown if the version is.
+func (mt maturity) stageAt(kubeVersion string) stage {
+	if kubeVersion == "" {
+		return stageUnknown
+	}
+	for _, ms := range []struct {
+		version string
+		stage   stage
+	}{
+		{mt.GA, stageGA},
+		{mt.Beta, stageBeta},
+		{mt.Alpha, stageAlpha},
+	} {
+		if ms.version == "" {
+			continue
+		}
+		ok, err := isAPIVersionAtLeast(kubeVersion, ms.version)
+		if err != nil {
+			return stageUnknown
+		}
+		if ok {
+			return ms.stage
+		}
+	}
+	return stageUnavailable
+}
+
+// the feature gates the topology-aware scheduling depends on
+var requiredFeatureGates = []struct {
+	name     string
+	maturity maturity
+}{
+	{"CPUManager", maturity{Beta: "1.10", GA: "1.26"}},
+	{"TopologyManager", maturity{Alpha: "1.16", Beta: "1.18", GA: "1.27"}},
+	{"MemoryManager", maturity{Alpha: "1.21", Beta: "1.22", GA: "1.32"}},
+	{"KubeletPodResourcesGetAllocatable", maturity{Alpha: "1.21", Beta: kubeMinVersionGetAllocatable, GA: "1.28"}},
+}
+
+// policyOptions describes the policy options of a kubelet manager and the feature gates guarding them
+type policyOptions struct {
+	gate      string
+	maturity  maturity
+	alphaGate string
+	betaGate  string
+	options   map[string]maturity
+}
+
+var topologyManagerPolicyOptions = policyOptions{
+	gate:      "TopologyManagerPolicyOptions",
+	maturity:  maturity{Alpha: "1.26", Beta: "1.28", GA: "1.32"},
+	alphaGate: "TopologyManagerPolicyAlphaOptions",
+	betaGate:  "TopologyManagerPolicyBetaOptions",
+	options: map[string]maturity{
+		"prefer-closest-numa-nodes": {Alpha: "1.26", Beta: "1.28", GA: "1.32"},
+		"max-allowable-numa-nodes":  {Beta: "1.31"},
+	},
+}
+
+var cpuManagerPolicyOptions = policyOptions{
+	gate:      "CPUManagerPolicyOptions",
+	maturity:  maturity{Alpha: "1.22", Beta: "1.23", GA: "1.33"},
+	alphaGate: "CPUManagerPolicyAlphaOptions",
+	betaGate:  "CPUManagerPolicyBetaOptions",
+	options: map[string]maturity{
+		"full-pcpus-only":                  {Alpha: "1.22", Beta: "1.23", GA: "1.33"},
+		"distribute-cpus-across-numa":      {Alpha: "1.23", Beta: "1.33"},
+		"align-by-socket":                  {Alpha: "1.25"},
+		"distribute-cpus-across-cores":     {Alpha: "1.31"},
+		"strict-cpu-reservation":           {Alpha: "1.32", Beta: "1.33"},
+		"prefer-align-cpus-by-uncorecache": {Alpha: "1.32", Beta: "1.34"},
+	},
+}
+
+func topologyRules() []Rule {
+	return []Rule{
+		NewRule(RuleInfo{
+			ID:          RuleTopologyManagerScope,
+			Description: "the topology manager scope is " + TopologyManagerScopeContainer + " or " + TopologyManagerScopePod,
+			Severity:    SeverityError,
+			Scope:       ScopeNode,
+		}, func(in RuleInput) []ValidationResult {
+			switch in.KubeletConfig.TopologyManagerScope {
+			case "", TopologyManagerScopeContainer, TopologyManagerScopePod:
+				return nil
+			}
+			return []ValidationResult{
+				{
+					Node:      in.NodeName,
+					Area:      AreaKubelet,
+					Component: ComponentTopologyManager,
+					Setting:   "scope",
+					Expected:  TopologyManagerScopeContainer + " or " + TopologyManagerScopePod,
+					Detected:  in.KubeletConfig.TopologyManagerScope,
+				},
+			}
+		}),
+		NewRule(RuleInfo{
+			ID:          RuleTopologyManagerScopeConsistency,
+			Description: "all the nodes use the same topology manager scope",
+			Severity:    SeverityWarning,
+			Scope:       ScopeNodes,
+		}, checkTopologyManagerScopeConsistency),
+		NewRule(RuleInfo{
+			ID:          RuleTopologyManagerPolicyOptions,
+			Description: "the topology manager policy options are supported and enabled",
+			Severity:    SeverityError,
+			Scope:       ScopeNode,
+		}, func(in RuleInput) []ValidationResult {
+			conf := in.KubeletConfig
+			if len(conf.TopologyManagerPolicyOptions) > 0 && (conf.TopologyManagerPolicy == "" || conf.TopologyManagerPolicy == kubeletconfigv1beta1.NoneTopologyManagerPolicy) {
+				return []ValidationResult{
+					{
+						Node:      in.NodeName,
+						Area:      AreaKubelet,
+						Component: ComponentTopologyManager,
+						Setting:   "policy options",
+						Expected:  "no options with policy " + kubeletconfigv1beta1.NoneTopologyManagerPolicy,
+						Detected:  strings.Join(sortedKeys(conf.TopologyManagerPolicyOptions), ","),
+					},
+				}
+			}
+			return checkPolicyOptions(in, ComponentTopologyManager, topologyManagerPolicyOptions, conf.TopologyManagerPolicyOptions)
+		}),
+		NewRule(RuleInfo{
+			ID:          RuleCPUManagerPolicyOptions,
+			Description: "the CPU manager policy options are supported and enabled",
+			Severity:    SeverityError,
+			Scope:       ScopeNode,
+		}, func(in RuleInput) []ValidationResult {
+			conf := in.KubeletConfig
+			if len(conf.CPUManagerPolicyOptions) > 0 && conf.CPUManagerPolicy != ExpectedCPUManagerPolicy {
+				return []ValidationResult{
+					{
+						Node:      in.NodeName,
+						Area:      AreaKubelet,
+						Component: ComponentCPUManager,
+						Setting:   "policy options",
+						Expected:  "no options with policy " + conf.CPUManagerPolicy,
+						Detected:  strings.Join(sortedKeys(conf.CPUManagerPolicyOptions), ","),
+					},
+				}
+			}
+			return checkPolicyOptions(in, ComponentCPUManager, cpuManagerPolicyOptions, conf.CPUManagerPolicyOptions)
+		}),
+		NewRule(RuleInfo{
+			ID:          RuleFeatureGates,
+			Description: "the feature gates the topology-aware scheduling needs are enabled",
+			Severity:    SeverityError,
+			Scope:       ScopeNode,
+		}, func(in RuleInput) []ValidationResult {
+			vrs := []ValidationResult{}
+			for _, fg := range requiredFeatureGates {
+				if vr, ok := checkFeatureGate(in, fg.name, fg.maturity); !ok {
+					vrs = append(vrs, vr)
+				}
+			}
+			return vrs
+		}),
+		NewRule(RuleInfo{
+			ID:          RuleReservedMemoryNUMACoverage,
+			Description: "memory is reserved on all the NUMA nodes",
+			Severity:    SeverityError,
+			Scope:       ScopeNode,
+		}, func(in RuleInput) []ValidationResult {
+			// no reserved memory at all is reported by its own rule; the topology may be unknown, like offline.
+			if len(in.KubeletConfig.ReservedMemory) == 0 || len(in.NUMANodes) == 0 {
+				return nil
+			}
+			reserved := make(map[int32]bool)
+			for _, rm := range in.KubeletConfig.ReservedMemory {
+				reserved[rm.NumaNode] = true
+			}
+			missing := []string{}
+			for _, numaNode := range in.NUMANodes {
+				if !reserved[int32(numaNode)] {
+					missing = append(missing, strconv.Itoa(numaNode))
+				}
+			}
+			if len(missing) == 0 {
+				return nil
+			}
+			return []ValidationResult{
+				{
+					Node:      in.NodeName,
+					Area:      AreaKubelet,
+					Component: ComponentMemoryManager,
+					Setting:   "reserved memory",
+					Expected:  "reserved memory on all NUMA nodes",
+					Detected:  "no reserved memory on NUMA nodes " + strings.Join(missing, ","),
+				},
+			}
+		}),
+	}
+}
+
+func checkTopologyManagerScopeConsistency(in RuleInput) []ValidationResult {
+	nodesByScope := make(map[string][]string)
+	for nodeName, conf := range in.KubeletConfigs {
+		if conf == nil {
+			continue
+		}
+		scope := conf.TopologyManagerScope
+		if scope == "" {
+			scope = TopologyManagerScopeContainer // kubelet default
+		}
+		nodesByScope[scope] = append(nodesByScope[scope], nodeName)
+	}
+	if len(nodesByScope) <= 1 {
+		return nil
+	}
+	detected := []string{}
+	for _, scope := range sortedKeys(nodesByScope) {
+		nodeNames := nodesByScope[scope]
+		sort.Strings(nodeNames)
+		detected = append(detected, fmt.Sprintf("%s on %s", scope, strings.Join(nodeNames, ",")))
+	}
+	return []ValidationResult{
+		{
+			/* no specific nodes: the mismatch involves many */
+			Area:      AreaCluster,
+			Component: ComponentTopologyManager,
+			Setting:   "scope",
+			Expected:  "same scope on all nodes",
+			Detected:  strings.Join(detected, "; "),
+		},
+	}
+}
+
+func checkPolicyOptions(in RuleInput, component string, po policyOptions, options map[string]string) []ValidationResult {
+	if len(options) == 0 {
+		return nil
+	}
+	kubeVersion := in.KubeVersion()
+	vrs := []ValidationResult{}
+	if vr, ok := checkFeatureGate(in, po.gate, po.maturity); !ok {
+		vrs = append(vrs, vr)
+	}
+	alphaEnabled := in.KubeletConfig.FeatureGates[po.alphaGate]
+	betaEnabled, ok := in.KubeletConfig.FeatureGates[po.betaGate]
+	if !ok {
+		betaEnabled = true
+	}
+	for _, name := range sortedKeys(options) {
+		vr := ValidationResult{
+			Node:      in.NodeName,
+			Area:      AreaKubelet,
+			Component: component,
+			Setting:   "policy options",
+		}
+		mt, known := po.options[name]
+		if !known {
+			vr.Expected = "known option"
+			vr.Detected = name
+			vrs = append(vrs, vr)
+			continue
+		}
+		switch mt.stageAt(kubeVersion) {
+		case stageUnavailable:
+			vr.Expected = fmt.Sprintf("option %q available", name)
+			vr.Detected = "kubernetes " + kubeVersion
+		case stageAlpha:
+			if alphaEnabled {
+				continue
+			}
+			vr.Expected = fmt.Sprintf("option %q enabled by %s", name, po.alphaGate)
+			vr.Detected = "feature gate disabled"
+		case stageBeta:
+			if betaEnabled {
+				continue
+			}
+			vr.Expected = fmt.Sprintf("option %q enabled by %s", name, po.betaGate)
+			vr.Detected = "feature gate disabled"
+		default:
+			continue
+		}
+		vrs = append(vrs, vr)
+	}
+	return vrs
+}
+
+// checkFeatureGate verifies the feature gate is enabled, explicitly when alpha. If the kubernetes version
+// is unknown, it only checks the feature gate is not explicitly disabled.
+func checkFeatureGate(in RuleInput, name string, mt maturity) (ValidationResult, bool) {
+	vr := ValidationResult{
+		Node:      in.NodeName,
+		Area:      AreaKubelet,
+		Component: ComponentFeatureGates,
+		Setting:   name,
+		Expected:  "enabled",
+	}
+	enabled, set := in.KubeletConfig.FeatureGates[name]
+	switch mt.stageAt(in.KubeVersion()) {
+	case stageUnavailable:
+		vr.Expected = "available"
+		vr.Detected = "kubernetes " + in.KubeVersion()
+		return vr, false
+	case stageAlpha:
+		if enabled {
+			return vr, true
+		}
+		vr.Detected = "disabled by default"
+		return vr, false
+	case stageGA:
+		return vr, true // locked
+	}
+	if set && !enabled {
+		vr.Detected = "disabled"
+		return vr, false
+	}
+	return vr, true
+}
+
+// collectNUMANodes returns the IDs of the NUMA nodes of the nodes, learned by their
+// NodeResourceTopology objects, if they are available.
+func (vd *Validator) collectNUMANodes() map[string][]int {
+	cli, err := clientutil.NewTopologyClient()
+	if err != nil {
+		vd.Log.V(2).Info("cannot learn the NUMA nodes", "error", err)
+		return nil
+	}
+	nrtList, err := cli.TopologyV1alpha2().NodeResourceTopologies().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		vd.Log.V(2).Info("cannot learn the NUMA nodes", "error", err)
+		return nil
+	}
+	numaNodes := make(map[string][]int, len(nrtList.Items))
+	for idx := range nrtList.Items {
+		nrt := &nrtList.Items[idx]
+		numaNodes[nrt.Name] = NUMANodesFromNRT(nrt)
+	}
+	return numaNodes
+}
+
+// NUMANodesFromNRT returns the IDs of the NUMA nodes described by the NodeResourceTopology zones.
+func NUMANodesFromNRT(nrt *nrtv1alpha2.NodeResourceTopology) []int {
+	numaNodes := []int{}
+	for _, zone := range nrt.Zones {
+		if zone.Type != "Node" {
+			continue
+		}
+		id, ok := strings.CutPrefix(zone.Name, "node-")
+		if !ok {
+			continue
+		}
+		numaNode, err := strconv.Atoi(id)
+		if err != nil {
+			continue
+		}
+		numaNodes = append(numaNodes, numaNode)
+	}
+	sort.Ints(numaNodes)
+	return numaNodes
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,296 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/version"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+
+	nrtv1alpha2 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha2"
+)
+
+func TestTopologyRules(t *testing.T) {
+	nodeName := "worker-0"
+
+	testCases := []struct {
+		name        string
+		rule        string
+		kubeletConf *kubeletconfigv1beta1.KubeletConfiguration
+		nodeVersion string
+		numaNodes   []int
+		expected    []ValidationResult
+	}{
+		{
+			name:        "default scope",
+			rule:        RuleTopologyManagerScope,
+			kubeletConf: &kubeletconfigv1beta1.KubeletConfiguration{},
+			expected:    []ValidationResult{},
+		},
+		{
+			name: "pod scope",
+			rule: RuleTopologyManagerScope,
+			kubeletConf: &kubeletconfigv1beta1.KubeletConfiguration{
+				TopologyManagerScope: TopologyManagerScopePod,
+			},
+			expected: []ValidationResult{},
+		},
+		{
+			name: "invalid scope",
+			rule: RuleTopologyManagerScope,
+			kubeletConf: &kubeletconfigv1beta1.KubeletConfiguration{
+				TopologyManagerScope: "node",
+			},
+			expected: []ValidationResult{
+				{Node: nodeName, Area: AreaKubelet, Component: ComponentTopologyManager, Setting: "scope"},
+			},
+		},
+		{
+			name: "topology manager beta option",
+			rule: RuleTopologyManagerPolicyOptions,
+			kubeletConf: &kubeletconfigv1beta1.KubeletConfiguration{
+				TopologyManagerPolicy:        ExpectedTopologyManagerPolicy,
+				TopologyManagerPolicyOptions: map[string]string{"prefer-closest-numa-nodes": "true"},
+			},
+			nodeVersion: "v1.29.1",
+			expected:    []ValidationResult{},
+		},
+		{
+			name: "topology manager alpha option",
+			rule: RuleTopologyManagerPolicyOptions,
+			kubeletConf: &kubeletconfigv1beta1.KubeletConfiguration{
+				TopologyManagerPolicy:        ExpectedTopologyManagerPolicy,
+				TopologyManagerPolicyOptions: map[string]string{"prefer-closest-numa-nodes": "true"},
+			},
+			nodeVersion: "v1.26.3",
+			expected: []ValidationResult{
+				{Node: nodeName, Area: AreaKubelet, Component: ComponentFeatureGates, Setting: "TopologyManagerPolicyOptions"},
+				{Node: nodeName, Area: AreaKubelet, Component: ComponentTopologyManager, Setting: "policy options"},
+			},
+		},
+		{
+			name: "topology manager alpha option enabled",
+			rule: RuleTopologyManagerPolicyOptions,
+			kubeletConf: &kubeletconfigv1beta1.KubeletConfiguration{
+				TopologyManagerPolicy:        ExpectedTopologyManagerPolicy,
+				TopologyManagerPolicyOptions: map[string]string{"prefer-closest-numa-nodes": "true"},
+				FeatureGates: map[string]bool{
+					"TopologyManagerPolicyOptions":      true,
+					"TopologyManagerPolicyAlphaOptions": true,
+				},
+			},
+			nodeVersion: "v1.26.3",
+			expected:    []ValidationResult{},
+		},
+		{
+			name: "topology manager option unavailable",
+			rule: RuleTopologyManagerPolicyOptions,
+			kubeletConf: &kubeletconfigv1beta1.KubeletConfiguration{
+				TopologyManagerPolicy:        ExpectedTopologyManagerPolicy,
+				TopologyManagerPolicyOptions: map[string]string{"max-allowable-numa-nodes": "10"},
+			},
+			nodeVersion: "v1.30.0",
+			expected: []ValidationResult{
+				{Node: nodeName, Area: AreaKubelet, Component: ComponentTopologyManager, Setting: "policy options"},
+			},
+		},
+		{
+			name: "topology manager options with none policy",
+			rule: RuleTopologyManagerPolicyOptions,
+			kubeletConf: &kubeletconfigv1beta1.KubeletConfiguration{
+				TopologyManagerPolicyOptions: map[string]string{"prefer-closest-numa-nodes": "true"},
+			},
+			expected: []ValidationResult{
+				{Node: nodeName, Area: AreaKubelet, Component: ComponentTopologyManager, Setting: "policy options"},
+			},
+		},
+		{
+			name: "cpu manager unknown option",
+			rule: RuleCPUManagerPolicyOptions,
+			kubeletConf: &kubeletconfigv1beta1.KubeletConfiguration{
+				CPUManagerPolicy:        ExpectedCPUManagerPolicy,
+				CPUManagerPolicyOptions: map[string]string{"full-pcpus-only": "true", "foobar": "true"},
+			},
+			nodeVersion: "v1.30.0",
+			expected: []ValidationResult{
+				{Node: nodeName, Area: AreaKubelet, Component: ComponentCPUManager, Setting: "policy options"},
+			},
+		},
+		{
+			name: "cpu manager beta options disabled",
+			rule: RuleCPUManagerPolicyOptions,
+			kubeletConf: &kubeletconfigv1beta1.KubeletConfiguration{
+				CPUManagerPolicy:        ExpectedCPUManagerPolicy,
+				CPUManagerPolicyOptions: map[string]string{"full-pcpus-only": "true"},
+				FeatureGates:            map[string]bool{"CPUManagerPolicyBetaOptions": false},
+			},
+			nodeVersion: "v1.30.0",
+			expected: []ValidationResult{
+				{Node: nodeName, Area: AreaKubelet, Component: ComponentCPUManager, Setting: "policy options"},
+			},
+		},
+		{
+			name: "cpu manager options with none policy",
+			rule: RuleCPUManagerPolicyOptions,
+			kubeletConf: &kubeletconfigv1beta1.KubeletConfiguration{
+				CPUManagerPolicy:        "none",
+				CPUManagerPolicyOptions: map[string]string{"full-pcpus-only": "true"},
+			},
+			expected: []ValidationResult{
+				{Node: nodeName, Area: AreaKubelet, Component: ComponentCPUManager, Setting: "policy options"},
+			},
+		},
+		{
+			name:        "feature gates on recent version",
+			rule:        RuleFeatureGates,
+			kubeletConf: &kubeletconfigv1beta1.KubeletConfiguration{},
+			nodeVersion: "v1.30.0",
+			expected:    []ValidationResult{},
+		},
+		{
+			name:        "feature gates on old version",
+			rule:        RuleFeatureGates,
+			kubeletConf: &kubeletconfigv1beta1.KubeletConfiguration{},
+			nodeVersion: "v1.21.4",
+			expected: []ValidationResult{
+				{Node: nodeName, Area: AreaKubelet, Component: ComponentFeatureGates, Setting: "MemoryManager"},
+				{Node: nodeName, Area: AreaKubelet, Component: ComponentFeatureGates, Setting: "KubeletPodResourcesGetAllocatable"},
+			},
+		},
+		{
+			name: "feature gates enabled on old version",
+			rule: RuleFeatureGates,
+			kubeletConf: &kubeletconfigv1beta1.KubeletConfiguration{
+				FeatureGates: map[string]bool{
+					"MemoryManager":                     true,
+					"KubeletPodResourcesGetAllocatable": true,
+				},
+			},
+			nodeVersion: "v1.21.4",
+			expected:    []ValidationResult{},
+		},
+		{
+			name: "feature gates disabled on unknown version",
+			rule: RuleFeatureGates,
+			kubeletConf: &kubeletconfigv1beta1.KubeletConfiguration{
+				FeatureGates: map[string]bool{"MemoryManager": false},
+			},
+			expected: []ValidationResult{
+				{Node: nodeName, Area: AreaKubelet, Component: ComponentFeatureGates, Setting: "MemoryManager"},
+			},
+		},
+		{
+			name: "reserved memory on all NUMA nodes",
+			rule: RuleReservedMemoryNUMACoverage,
+			kubeletConf: &kubeletconfigv1beta1.KubeletConfiguration{
+				ReservedMemory: []kubeletconfigv1beta1.MemoryReservation{{NumaNode: 0}, {NumaNode: 1}},
+			},
+			numaNodes: []int{0, 1},
+			expected:  []ValidationResult{},
+		},
+		{
+			name: "reserved memory missing NUMA nodes",
+			rule: RuleReservedMemoryNUMACoverage,
+			kubeletConf: &kubeletconfigv1beta1.KubeletConfiguration{
+				ReservedMemory: []kubeletconfigv1beta1.MemoryReservation{{NumaNode: 0}},
+			},
+			numaNodes: []int{0, 1},
+			expected: []ValidationResult{
+				{Node: nodeName, Area: AreaKubelet, Component: ComponentMemoryManager, Setting: "reserved memory"},
+			},
+		},
+		{
+			name: "reserved memory unknown NUMA nodes",
+			rule: RuleReservedMemoryNUMACoverage,
+			kubeletConf: &kubeletconfigv1beta1.KubeletConfiguration{
+				ReservedMemory: []kubeletconfigv1beta1.MemoryReservation{{NumaNode: 0}},
+			},
+			expected: []ValidationResult{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rs, err := DefaultRegistry().Select(Selection{Enable: []string{tc.rule}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			in := RuleInput{
+				NodeName:      nodeName,
+				KubeletConfig: tc.kubeletConf,
+				NUMANodes:     tc.numaNodes,
+			}
+			if tc.nodeVersion != "" {
+				in.NodeVersion = &version.Info{GitVersion: tc.nodeVersion}
+			}
+			got := rs.ValidateNode(in)
+			if !matchValidationResults(tc.expected, got) {
+				t.Errorf("validation failed:\nexpected=%#v\ngot=%#v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestTopologyManagerScopeConsistency(t *testing.T) {
+	rs, err := DefaultRegistry().Select(Selection{Enable: []string{RuleTopologyManagerScopeConsistency}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	vrs := rs.ValidateKubeletConfigs("", "", map[string]*kubeletconfigv1beta1.KubeletConfiguration{
+		"worker-0": {},
+		"worker-1": {TopologyManagerScope: TopologyManagerScopeContainer},
+	})
+	if len(vrs) != 0 {
+		t.Errorf("unexpected results: %#v", vrs)
+	}
+
+	vrs = rs.ValidateKubeletConfigs("", "", map[string]*kubeletconfigv1beta1.KubeletConfiguration{
+		"worker-0": {},
+		"worker-1": {TopologyManagerScope: TopologyManagerScopePod},
+		"worker-2": {TopologyManagerScope: TopologyManagerScopeContainer},
+	})
+	expected := ValidationResult{
+		Area:      AreaCluster,
+		Component: ComponentTopologyManager,
+		Setting:   "scope",
+		Expected:  "same scope on all nodes",
+		Detected:  "container on worker-0,worker-2; pod on worker-1",
+		Rule:      RuleTopologyManagerScopeConsistency,
+		Severity:  SeverityWarning,
+	}
+	if len(vrs) != 1 || vrs[0] != expected {
+		t.Errorf("got %#v expected %#v", vrs, expected)
+	}
+}
+
+func TestNUMANodesFromNRT(t *testing.T) {
+	nrt := &nrtv1alpha2.NodeResourceTopology{
+		Zones: nrtv1alpha2.ZoneList{
+			{Name: "node-1", Type: "Node"},
+			{Name: "node-0", Type: "Node"},
+			{Name: "socket-0", Type: "Socket"},
+			{Name: "foo", Type: "Node"},
+		},
+	}
+	got := NUMANodesFromNRT(nrt)
+	if expected := []int{0, 1}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v expected %v", got, expected)
+	}
+}
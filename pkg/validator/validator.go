@@ -18,34 +18,50 @@ package validator
 
 import (
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/discovery"
 
 	"github.com/go-logr/logr"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/clientutil"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
 )
 
 const (
 	AreaCluster = "cluster"
 	AreaKubelet = "kubelet"
+	// AreaNode reports the nodes which can't be validated, like the unreachable ones
+	AreaNode = "node"
 )
 
 type Validator struct {
 	Log logr.Logger
+	// Concurrency and NodeTimeout tune the collection of the kubelet configuration
+	// of the nodes. Zero values use the kubeletconfig package defaults.
+	Concurrency int
+	NodeTimeout time.Duration
+	// Rules selects the rules to run. Nil runs the default rule set.
+	Rules *RuleSet
+	// Platform restricts the rules to the ones applicable to it, unless unknown.
+	Platform platform.Platform
 
-	results       []ValidationResult
-	serverVersion *version.Info
+	results          []ValidationResult
+	clusterValidated bool
+	serverVersion    *version.Info
+	numaNodes        map[string][]int
 }
 
 func NewValidatorWithDiscoveryClient(logger logr.Logger, cli *discovery.DiscoveryClient) (*Validator, error) {
 	vd := &Validator{
 		Log: logger,
 	}
-	_, err := vd.ValidateClusterVersion(cli)
+	// the results of the cluster rules are recorded once the rule selection is final, see Results
+	ver, err := cli.ServerVersion()
 	if err != nil {
 		return nil, err
 	}
+	vd.serverVersion = ver
 	return vd, nil
 }
 
@@ -57,10 +73,41 @@ func NewValidator(logger logr.Logger) (*Validator, error) {
 	return NewValidatorWithDiscoveryClient(logger, cli)
 }
 
+// Results returns the results of the validations run so far, starting with the ones of the
+// cluster rules on the server version learned when creating the validator.
 func (vd *Validator) Results() []ValidationResult {
+	vd.recordClusterResults()
 	return vd.results
 }
 
+// recordClusterResults runs the cluster rules and records their results, unless they ran already.
+// The cluster rules run lazily so the rules selected after creating the validator apply.
+func (vd *Validator) recordClusterResults() {
+	if vd.clusterValidated {
+		return
+	}
+	vd.clusterValidated = true
+	vrs := vd.ruleSet().ValidateCluster(vd.clusterRuleInput())
+	vd.results = append(vrs, vd.results...)
+}
+
+func (vd *Validator) ruleSet() *RuleSet {
+	if vd.Rules != nil {
+		return vd.Rules
+	}
+	return DefaultRuleSet()
+}
+
+func (vd *Validator) clusterRuleInput() RuleInput {
+	in := RuleInput{
+		Platform: vd.Platform,
+	}
+	if vd.serverVersion != nil {
+		in.ServerVersion = vd.serverVersion.GitVersion
+	}
+	return in
+}
+
 type ValidationResult struct {
 	Node      string `json:"node"`
 	Area      string `json:"area"`
@@ -68,9 +115,16 @@ type ValidationResult struct {
 	Setting   string `json:"setting"`
 	Expected  string `json:"expected"`
 	Detected  string `json:"detected"`
+	// Rule is the ID of the rule reporting the result, if any
+	Rule     string   `json:"rule,omitempty"`
+	Severity Severity `json:"severity"`
 }
 
 func (vr ValidationResult) String() string {
+	if vr.Area == AreaNode {
+		return fmt.Sprintf("Cannot validate node %q: component %q: expected %q detected %q",
+			vr.Node, vr.Component, vr.Expected, vr.Detected)
+	}
 	if vr.Area == AreaCluster {
 		return fmt.Sprintf("Incorrect configuration of cluster: component %q setting %q: expected %q detected %q",
 			vr.Component, vr.Setting, vr.Expected, vr.Detected)
@@ -16,7 +16,19 @@
 
 package validator
 
-import "testing"
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+)
 
 func TestValidationResultToString(t *testing.T) {
 	vr := ValidationResult{
@@ -30,4 +42,77 @@ func TestValidationResultToString(t *testing.T) {
 	if vr.String() == "" {
 		t.Fatalf("empty string from nonempty ValidationResult")
 	}
+
+	vr = ValidationResult{
+		Node:      "any",
+		Area:      AreaNode,
+		Component: ComponentConfigz,
+		Expected:  "reachable",
+		Detected:  "unreachable: 503",
+	}
+	if got := vr.String(); got != `Cannot validate node "any": component "configz": expected "reachable" detected "unreachable: 503"` {
+		t.Errorf("unexpected string: %q", got)
+	}
+}
+
+func TestValidatorRecordsClusterResults(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/version" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(version.Info{GitVersion: "v1.20.4"})
+	}))
+	defer srv.Close()
+
+	cli, err := discovery.NewDiscoveryClientForConfig(&rest.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatalf("cannot create the discovery client: %v", err)
+	}
+
+	testCases := []struct {
+		name           string
+		disable        []string
+		expectedResult bool
+	}{
+		{
+			name:           "default rules",
+			expectedResult: true,
+		},
+		{
+			name:    "cluster version rule disabled after creation",
+			disable: []string{RuleClusterVersion},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vd, err := NewValidatorWithDiscoveryClient(testr.New(t), cli)
+			if err != nil {
+				t.Fatalf("cannot create the validator: %v", err)
+			}
+			if len(tc.disable) > 0 {
+				vd.Rules, err = DefaultRegistry().Select(Selection{Disable: tc.disable})
+				if err != nil {
+					t.Fatalf("cannot select the rules: %v", err)
+				}
+			}
+			// library path: the nodes are validated one by one
+			vd.ValidateNodeKubeletConfig("worker-0", nil, &kubeletconfigv1beta1.KubeletConfiguration{})
+
+			found := 0
+			for _, vr := range vd.Results() {
+				if vr.Area == AreaCluster && vr.Component == ComponentAPIVersion {
+					found++
+				}
+			}
+			if tc.expectedResult && found != 1 {
+				t.Errorf("expected one cluster version result, found %d: %v", found, vd.Results())
+			}
+			if !tc.expectedResult && found != 0 {
+				t.Errorf("unexpected cluster version results: %v", vd.Results())
+			}
+		})
+	}
 }
@@ -316,10 +316,10 @@ func expectSchedulerRunning(ctx context.Context, cli client.Client) {
 	gomega.Expect(ok).To(gomega.BeTrue(), "empty config data for %q", manifests.SchedulerConfigFileName)
 
 	allParams, err := manifests.DecodeSchedulerProfilesFromData([]byte(data))
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
 	gomega.Expect(len(allParams)).To(gomega.Equal(1), "unexpected params: %#v", allParams)
 
 	params := allParams[0] // TODO: smarter find
-	gomega.Expect(err).ToNot(gomega.HaveOccurred())
 	gomega.Expect(params.Cache).ToNot(gomega.BeNil(), "no data for scheduler cache config")
 	gomega.Expect(params.Cache.ResyncPeriodSeconds).ToNot(gomega.BeNil(), "no data for scheduler cache resync period")
 